@@ -0,0 +1,210 @@
+// Package catalog provides constructors for well-known matroids.
+// They are meant as fixtures for tests and as examples; every matroid is built on
+// Elements of this package labelled as described in each constructor.
+package catalog
+
+import (
+	"fmt"
+	"strconv"
+
+	matroid "github.com/yuichiro12/go-matroid"
+)
+
+const ElementType matroid.ElementType = "CATALOG"
+
+// Element is an element of a catalog matroid, identified by its label.
+type Element string
+
+func (e Element) GetType() matroid.ElementType {
+	return ElementType
+}
+
+func (e Element) Key() string {
+	return string(e)
+}
+
+func (e Element) Value() interface{} {
+	return string(e)
+}
+
+func (e Element) Weight() float64 {
+	return 0
+}
+
+// labels() returns the elements labelled by prefix followed by 1, ..., n.
+func labels(prefix string, n int) []matroid.Element {
+	var elms []matroid.Element
+	for i := 1; i <= n; i++ {
+		elms = append(elms, Element(prefix+strconv.Itoa(i)))
+	}
+	return elms
+}
+
+func letters(s string) []matroid.Element {
+	var elms []matroid.Element
+	for _, c := range s {
+		elms = append(elms, Element(string(c)))
+	}
+	return elms
+}
+
+func mustGF(p int, elms []matroid.Element, columns [][]int) *matroid.GFMatroid {
+	g, err := matroid.NewGFMatroid(p, elms, columns)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// Uniform() returns the uniform matroid U(r,n) on elements "1", ..., "n".
+// Every set of at most r elements is independent.
+// U(r,n) is representable over every sufficiently large field; U(2,4) is the excluded minor for binary matroids.
+func Uniform(r, n int) *matroid.UniformMatroid {
+	if r < 0 || r > n {
+		panic(fmt.Sprintf("invalid uniform matroid U(%d,%d)", r, n))
+	}
+	return matroid.NewUniformMatroid(matroid.NewSet(ElementType, labels("", n)...), r)
+}
+
+// Fano() returns the Fano plane F7 on elements "a", ..., "g": rank 3, 7 elements.
+// Its seven lines are abd, ace, afg, bcf, beg, cdg and def.
+// F7 is binary and representable exactly over fields of characteristic 2; it is not regular.
+func Fano() *matroid.GFMatroid {
+	return mustGF(2, letters("abcdefg"), [][]int{
+		{1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {1, 1, 0}, {1, 0, 1}, {0, 1, 1}, {1, 1, 1},
+	})
+}
+
+// NonFano() returns the non-Fano matroid F7- on elements "a", ..., "g": rank 3, 7 elements.
+// It is F7 with the line def relaxed to a base.
+// F7- is ternary and representable exactly over fields of characteristic other than 2.
+func NonFano() *matroid.GFMatroid {
+	return mustGF(3, letters("abcdefg"), [][]int{
+		{1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {1, 1, 0}, {1, 0, 1}, {0, 1, 1}, {1, 1, 1},
+	})
+}
+
+// Vamos() returns the Vámos matroid V8 on elements "a", "a'", ..., "d", "d'": rank 4, 8 elements.
+// It is sparse paving; its only non-spanning circuits are the five 4-sets formed by two of the pairs
+// {a,a'}, {b,b'}, {c,c'} and {d,d'} other than {c,c',d,d'}.
+// V8 is not representable over any field.
+func Vamos() *matroid.SparsePavingMatroid {
+	gs := matroid.NewSet(ElementType)
+	for _, c := range "abcd" {
+		gs.Add(Element(string(c)))
+		gs.Add(Element(string(c) + "'"))
+	}
+	pair := func(s string) *matroid.Set {
+		var elms []matroid.Element
+		for _, c := range s {
+			elms = append(elms, Element(string(c)), Element(string(c)+"'"))
+		}
+		return matroid.NewSet(ElementType, elms...)
+	}
+	m, err := matroid.NewSparsePavingMatroid(gs, 4, []*matroid.Set{
+		pair("ab"), pair("ac"), pair("ad"), pair("bc"), pair("bd"),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Pappus() returns the Pappus matroid on elements "a1", "a2", "a3", "b1", "b2", "b3", "c1", "c2", "c3":
+// rank 3, 9 elements. The points ai and bj lie on two lines, and c1, c2 and c3 are the intersections of
+// the lines a1b2 and a2b1, a1b3 and a3b1, and a2b3 and a3b2, which are collinear by Pappus's theorem.
+// Its nine lines are thus a1a2a3, b1b2b3, c1c2c3, a1b2c1, a2b1c1, a1b3c2, a3b1c2, a2b3c3 and a3b2c3.
+// The matroid is represented here over GF(7); it is also representable over the reals, but not binary or ternary.
+func Pappus() *matroid.GFMatroid {
+	elms := append(labels("a", 3), labels("b", 3)...)
+	elms = append(elms, labels("c", 3)...)
+	return mustGF(7, elms, [][]int{
+		{0, 0, 1}, {1, 0, 1}, {2, 0, 1},
+		{0, 1, 1}, {1, 1, 1}, {3, 1, 1},
+		{1, 1, 2}, {6, 2, 5}, {5, 1, 3},
+	})
+}
+
+// R10() returns the matroid R10 on elements "1", ..., "10": rank 5, 10 elements.
+// It is represented over GF(2) by the ten vectors with exactly three non-zero entries.
+// R10 is regular but neither graphic nor cographic; every single-element deletion is isomorphic to M(K3,3).
+func R10() *matroid.GFMatroid {
+	var columns [][]int
+	for v := 0; v < 1<<5; v++ {
+		c := make([]int, 5)
+		var ones int
+		for j := range c {
+			c[j] = v >> uint(j) & 1
+			ones += c[j]
+		}
+		if ones == 3 {
+			columns = append(columns, c)
+		}
+	}
+	return mustGF(2, labels("", 10), columns)
+}
+
+// Wheel() returns the cycle matroid of the wheel with n spokes "s1", ..., "sn" and rim edges
+// "r1", ..., "rn", where ri joins the ends of si and si+1: rank n, 2n elements.
+// Wheels are graphic, hence regular, and 3-connected for n >= 3.
+func Wheel(n int) *matroid.GFMatroid {
+	return wheel(n, false)
+}
+
+// Whirl() returns the whirl W^n with spokes "s1", ..., "sn" and rim "r1", ..., "rn": rank n, 2n elements.
+// It is Wheel(n) with the rim relaxed from a circuit-hyperplane to a base.
+// Whirls are ternary but not binary for n >= 2.
+func Whirl(n int) *matroid.GFMatroid {
+	return wheel(n, true)
+}
+
+// wheel() represents the wheel over GF(3) with rim edges ri = si - si+1; the whirl flips the sign of rn.
+func wheel(n int, whirl bool) *matroid.GFMatroid {
+	if n < 2 {
+		panic(fmt.Sprintf("a wheel needs at least 2 spokes, got %d", n))
+	}
+	var columns [][]int
+	for i := 0; i < n; i++ {
+		c := make([]int, n)
+		c[i] = 1
+		columns = append(columns, c)
+	}
+	for i := 0; i < n; i++ {
+		c := make([]int, n)
+		c[i], c[(i+1)%n] = 1, -1
+		if whirl && i == n-1 {
+			c[0] = 1
+		}
+		columns = append(columns, c)
+	}
+	return mustGF(3, append(labels("s", n), labels("r", n)...), columns)
+}
+
+// BinarySpike() returns the rank-r binary spike with tip "t" and legs {t, xi, yi} for i = 1, ..., r:
+// rank r, 2r+1 elements. BinarySpike(3) is isomorphic to the Fano plane.
+// Binary spikes are binary but not regular for r >= 3; deleting the tip gives the tipless binary spike.
+func BinarySpike(r int) *matroid.GFMatroid {
+	if r < 3 {
+		panic(fmt.Sprintf("a spike needs rank at least 3, got %d", r))
+	}
+	tip := make([]int, r)
+	columns := [][]int{tip}
+	for i := range tip {
+		tip[i] = 1
+	}
+	for i := 0; i < r; i++ {
+		x := make([]int, r)
+		x[i] = 1
+		columns = append(columns, x)
+	}
+	for i := 0; i < r; i++ {
+		y := make([]int, r)
+		for j := range y {
+			y[j] = 1
+		}
+		y[i] = 0
+		columns = append(columns, y)
+	}
+	elms := append([]matroid.Element{Element("t")}, labels("x", r)...)
+	return mustGF(2, append(elms, labels("y", r)...), columns)
+}
@@ -0,0 +1,93 @@
+package catalog
+
+import (
+	"testing"
+
+	matroid "github.com/yuichiro12/go-matroid"
+)
+
+func TestCatalog(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       matroid.Matroid
+		rank    int
+		size    int
+		binary  bool
+		ternary bool
+	}{
+		{name: "U(2,4)", m: Uniform(2, 4), rank: 2, size: 4, binary: false, ternary: true},
+		{name: "F7", m: Fano(), rank: 3, size: 7, binary: true, ternary: false},
+		{name: "F7-", m: NonFano(), rank: 3, size: 7, binary: false, ternary: true},
+		{name: "V8", m: Vamos(), rank: 4, size: 8, binary: false, ternary: false},
+		{name: "Pappus", m: Pappus(), rank: 3, size: 9, binary: false, ternary: false},
+		{name: "R10", m: R10(), rank: 5, size: 10, binary: true, ternary: true},
+		{name: "W4", m: Wheel(4), rank: 4, size: 8, binary: true, ternary: true},
+		{name: "W^3", m: Whirl(3), rank: 3, size: 6, binary: false, ternary: true},
+		{name: "Z4", m: BinarySpike(4), rank: 4, size: 9, binary: true, ternary: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := tt.m.GroundSet()
+			if gs.Cardinality() != tt.size {
+				t.Errorf("size mismatch. expected: %d, actual: %d", tt.size, gs.Cardinality())
+			}
+			if r := tt.m.Rank(gs); r != tt.rank {
+				t.Errorf("rank mismatch. expected: %d, actual: %d", tt.rank, r)
+			}
+			if g, _ := matroid.IsBinary(tt.m); (g != nil) != tt.binary {
+				t.Errorf("IsBinary() = %v, want %v", g != nil, tt.binary)
+			}
+			if g, _ := matroid.IsTernary(tt.m); (g != nil) != tt.ternary {
+				t.Errorf("IsTernary() = %v, want %v", g != nil, tt.ternary)
+			}
+		})
+	}
+}
+
+func TestRelations(t *testing.T) {
+	tests := []struct {
+		name string
+		m1   matroid.Matroid
+		m2   matroid.Matroid
+	}{
+		{name: "Z3 is F7", m1: BinarySpike(3), m2: Fano()},
+		{name: "W^2 is U(2,4)", m1: Whirl(2), m2: Uniform(2, 4)},
+		{name: "R10 is self dual", m1: matroid.Dual(R10()), m2: R10()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok, err := matroid.Isomorphic(tt.m1, tt.m2); err != nil || !ok {
+				t.Errorf("Isomorphic() = %v, %v, want true", ok, err)
+			}
+		})
+	}
+}
+
+func TestPappusLines(t *testing.T) {
+	lines := map[string]bool{
+		"a1a2a3": true, "b1b2b3": true, "c1c2c3": true,
+		"a1b2c1": true, "a2b1c1": true, "a1b3c2": true,
+		"a3b1c2": true, "a2b3c3": true, "a3b2c3": true,
+	}
+	m := Pappus()
+	elms := append(labels("a", 3), labels("b", 3)...)
+	elms = append(elms, labels("c", 3)...)
+	var dependent int
+	for i := 0; i < len(elms); i++ {
+		for j := i + 1; j < len(elms); j++ {
+			for k := j + 1; k < len(elms); k++ {
+				name := elms[i].Key() + elms[j].Key() + elms[k].Key()
+				s := matroid.NewSet(m.GroundSet().GetType(), elms[i], elms[j], elms[k])
+				if m.Independent(s) == lines[name] {
+					t.Errorf("dependence mismatch for %s. expected: %v, actual: %v", name, lines[name], !m.Independent(s))
+				}
+				if !m.Independent(s) {
+					dependent++
+				}
+			}
+		}
+	}
+	if dependent != len(lines) {
+		t.Errorf("number of lines mismatch. expected: %d, actual: %d", len(lines), dependent)
+	}
+}
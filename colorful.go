@@ -0,0 +1,102 @@
+package matroid
+
+import (
+	"fmt"
+	"sort"
+)
+
+// NoColorfulBaseError is returned when no base of a matroid respects the color capacities.
+// By Edmonds' min-max theorem the elements of Certificate span at most Rank(Certificate) and the
+// others provide at most the sum of the capacities of their colors, which together fall short of a base.
+type NoColorfulBaseError struct {
+	// Rank is the rank of the matroid.
+	Rank int
+	// Size is the largest number of elements of an independent set respecting the capacities.
+	Size        int
+	Certificate *Set
+}
+
+func (e *NoColorfulBaseError) Error() string {
+	return fmt.Sprintf("no colorful base exists: an independent set within the color capacities has at most %d elements, but the rank is %d",
+		e.Size, e.Rank)
+}
+
+// ColorfulBase() returns a base of m with at most caps[c] elements of each color c, where the color of
+// an element is colorOf(element). Colors missing from caps have capacity one, so nil caps asks for a
+// rainbow base. It returns a *NoColorfulBaseError if there is no such base.
+func ColorfulBase(m Matroid, colorOf func(Element) string, caps map[string]int) (*Set, error) {
+	p, err := colorPartition(m, colorOf, caps)
+	if err != nil {
+		return nil, err
+	}
+	s, u, err := IntersectionWithCertificate(m, p)
+	if err != nil {
+		return nil, err
+	}
+	if r := m.Rank(m.GroundSet()); s.Cardinality() < r {
+		return nil, &NoColorfulBaseError{Rank: r, Size: s.Cardinality(), Certificate: u}
+	}
+	return s, nil
+}
+
+// MaxWeightColorfulBase() works like ColorfulBase() and returns a colorful base of maximum total Weight().
+func MaxWeightColorfulBase(m Matroid, colorOf func(Element) string, caps map[string]int) (*Set, error) {
+	return weightedColorfulBase(m, colorOf, caps, func(e Element) float64 {
+		return e.Weight()
+	})
+}
+
+// MinWeightColorfulBase() works like ColorfulBase() and returns a colorful base of minimum total Weight().
+func MinWeightColorfulBase(m Matroid, colorOf func(Element) string, caps map[string]int) (*Set, error) {
+	return weightedColorfulBase(m, colorOf, caps, func(e Element) float64 {
+		return -e.Weight()
+	})
+}
+
+func weightedColorfulBase(m Matroid, colorOf func(Element) string, caps map[string]int, w func(Element) float64) (*Set, error) {
+	p, err := colorPartition(m, colorOf, caps)
+	if err != nil {
+		return nil, err
+	}
+	s := weightedIntersection(m, p, w)
+	if r := m.Rank(m.GroundSet()); s.Cardinality() < r {
+		// the certificate comes from the unweighted algorithm
+		_, u, err := IntersectionWithCertificate(m, p)
+		if err != nil {
+			return nil, err
+		}
+		return nil, &NoColorfulBaseError{Rank: r, Size: s.Cardinality(), Certificate: u}
+	}
+	return s, nil
+}
+
+// colorPartition() returns the partition matroid on the GroundSet of m with a block for each color.
+func colorPartition(m Matroid, colorOf func(Element) string, caps map[string]int) (*PartitionMatroid, error) {
+	gs := m.GroundSet()
+	blocks := make(map[string]*Set)
+	for _, e := range sortedElements(gs) {
+		c := colorOf(e)
+		if _, ok := blocks[c]; !ok {
+			blocks[c] = EmptySet(gs.GetType())
+		}
+		blocks[c].Add(e)
+	}
+	colors := make([]string, 0, len(blocks))
+	for c := range blocks {
+		colors = append(colors, c)
+	}
+	sort.Strings(colors)
+	p := make([]Partition, 0, len(colors))
+	for _, c := range colors {
+		capacity, ok := caps[c]
+		if !ok {
+			capacity = 1
+		}
+		block, err := NewPartition(blocks[c], capacity)
+		if err != nil {
+			return nil, fmt.Errorf("color %s: %s", c, err)
+		}
+		p = append(p, block)
+	}
+	return NewPartitionMatroidOn(gs, p, UncoveredLoops)
+}
@@ -0,0 +1,114 @@
+package matroid
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestWeightedIntersection(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i, c := range intersectionTestCases(t) {
+		m1, m2 := c[0], c[1]
+		for _, e := range m1.GroundSet().ToSlice() {
+			e.(*Arc).W = float64(rnd.Intn(10))
+		}
+		size, weight := 0, 0.0
+		for _, s := range subsetsOf(m1.GroundSet()) {
+			if !m1.Independent(s) || !m2.Independent(s) {
+				continue
+			}
+			if w := totalWeight(s); s.Cardinality() > size || s.Cardinality() == size && w > weight {
+				size, weight = s.Cardinality(), w
+			}
+		}
+		s, err := WeightedIntersection(m1, m2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !m1.Independent(s) || !m2.Independent(s) {
+			t.Errorf("case %d: %v is not a common independent set", i, s.ToSlice())
+		}
+		if s.Cardinality() != size {
+			t.Errorf("case %d: size mismatch. expected: %d, actual: %d", i, size, s.Cardinality())
+		}
+		if w := totalWeight(s); w != weight {
+			t.Errorf("case %d: weight mismatch. expected: %g, actual: %g", i, weight, w)
+		}
+	}
+}
+
+func TestColorfulBase(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+	colorOf := func(e Element) string {
+		return string(rune('a' + e.(*Arc).Id%4))
+	}
+	for k := 0; k < 20; k++ {
+		var arcs [][2]int64
+		for i := 0; i < 8; i++ {
+			arcs = append(arcs, [2]int64{rnd.Int63n(5), rnd.Int63n(5)})
+		}
+		d := newTestDigraph(0, arcs...)
+		for _, e := range d.A.ToSlice() {
+			e.(*Arc).W = float64(rnd.Intn(10))
+		}
+		m := NewGraphicMatroid(d)
+		caps := map[string]int{"a": 2}
+		if k%2 == 1 {
+			caps = nil
+		}
+
+		// brute force over the bases
+		r := m.Rank(m.GroundSet())
+		exists, maxWeight, minWeight := false, math.Inf(-1), math.Inf(1)
+		for _, s := range subsetsOf(m.GroundSet()) {
+			if s.Cardinality() != r || !m.Independent(s) || !withinCaps(s, colorOf, caps) {
+				continue
+			}
+			exists = true
+			maxWeight, minWeight = math.Max(maxWeight, totalWeight(s)), math.Min(minWeight, totalWeight(s))
+		}
+
+		for _, c := range []struct {
+			name   string
+			f      func(Matroid, func(Element) string, map[string]int) (*Set, error)
+			weight float64
+		}{
+			{"ColorfulBase", ColorfulBase, math.NaN()},
+			{"MaxWeightColorfulBase", MaxWeightColorfulBase, maxWeight},
+			{"MinWeightColorfulBase", MinWeightColorfulBase, minWeight},
+		} {
+			s, err := c.f(m, colorOf, caps)
+			if !exists {
+				e, ok := err.(*NoColorfulBaseError)
+				if !ok {
+					t.Errorf("case %d %s: NoColorfulBaseError expected, actual: %v", k, c.name, err)
+					continue
+				}
+				rest, _ := m.GroundSet().Complement(e.Certificate)
+				p, _ := colorPartition(m, colorOf, caps)
+				if bound := m.Rank(e.Certificate) + p.Rank(rest); bound != e.Size || e.Size >= e.Rank {
+					t.Errorf("case %d %s: invalid certificate. bound: %d, size: %d, rank: %d", k, c.name, bound, e.Size, e.Rank)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s.Cardinality() != r || !m.Independent(s) || !withinCaps(s, colorOf, caps) {
+				t.Errorf("case %d %s: %v is not a colorful base", k, c.name, s.ToSlice())
+			}
+			if w := totalWeight(s); !math.IsNaN(c.weight) && w != c.weight {
+				t.Errorf("case %d %s: weight mismatch. expected: %g, actual: %g", k, c.name, c.weight, w)
+			}
+		}
+	}
+}
+
+func TestColorfulBase_NegativeCapacity(t *testing.T) {
+	m := NewGraphicMatroid(newTestDigraph(0, [2]int64{1, 2}))
+	colorOf := func(Element) string { return "a" }
+	if _, err := ColorfulBase(m, colorOf, map[string]int{"a": -1}); err == nil {
+		t.Errorf("negative capacity is accepted")
+	}
+}
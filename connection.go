@@ -0,0 +1,149 @@
+package matroid
+
+import (
+	"errors"
+	"fmt"
+)
+
+const TaggedType ElementType = "TAGGED"
+
+// TaggedElement is an element of a matroid combined from two matroids.
+// Tag is 1 or 2 for elements coming from the first or the second matroid,
+// and 0 for the basepoint shared by both.
+type TaggedElement struct {
+	Tag     int
+	Element Element
+}
+
+func (t TaggedElement) GetType() ElementType {
+	return TaggedType
+}
+
+func (t TaggedElement) Key() string {
+	return fmt.Sprintf("%d/%s", t.Tag, t.Element.Key())
+}
+
+func (t TaggedElement) Value() interface{} {
+	return t.Element.Value()
+}
+
+func (t TaggedElement) Weight() float64 {
+	return t.Element.Weight()
+}
+
+// connection holds two matroids glued along the basepoints p1 and p2.
+type connection struct {
+	m1, m2 Matroid
+	p1, p2 Element
+}
+
+// split() returns the elements of s coming from each matroid, adding the basepoint to both if s contains it.
+func (c *connection) split(s *Set) (*Set, *Set, bool) {
+	s1, s2 := EmptySet(c.m1.GroundSet().GetType()), EmptySet(c.m2.GroundSet().GetType())
+	var base bool
+	for e := range s.Iter() {
+		t := e.(TaggedElement)
+		switch t.Tag {
+		case 0:
+			base = true
+			s1.Add(c.p1)
+			s2.Add(c.p2)
+		case 1:
+			s1.Add(t.Element)
+		case 2:
+			s2.Add(t.Element)
+		}
+	}
+	return s1, s2, base
+}
+
+func (c *connection) groundSet() *Set {
+	gs := NewSet(TaggedType, TaggedElement{Tag: 0, Element: c.p1})
+	for e := range c.m1.GroundSet().Iter() {
+		if e.Key() != c.p1.Key() {
+			gs.Add(TaggedElement{Tag: 1, Element: e})
+		}
+	}
+	for e := range c.m2.GroundSet().Iter() {
+		if e.Key() != c.p2.Key() {
+			gs.Add(TaggedElement{Tag: 2, Element: e})
+		}
+	}
+	return gs
+}
+
+// rank() is the rank function of the parallel connection.
+// If a basepoint is a loop, the parallel connection is the direct sum of its matroid
+// and the contraction of the other matroid by its basepoint.
+func (c *connection) rank(s *Set) int {
+	s1, s2, base := c.split(s)
+	with1, with2 := s1.Clone(), s2.Clone()
+	with1.Add(c.p1)
+	with2.Add(c.p2)
+	switch {
+	case c.m1.Rank(NewSet(c.m1.GroundSet().GetType(), c.p1)) == 0:
+		return c.m1.Rank(s1) + c.m2.Rank(with2) - c.m2.Rank(NewSet(c.m2.GroundSet().GetType(), c.p2))
+	case c.m2.Rank(NewSet(c.m2.GroundSet().GetType(), c.p2)) == 0:
+		return c.m1.Rank(with1) - 1 + c.m2.Rank(s2)
+	case base:
+		return c.m1.Rank(s1) + c.m2.Rank(s2) - 1
+	}
+	return min(c.m1.Rank(s1)+c.m2.Rank(s2), c.m1.Rank(with1)+c.m2.Rank(with2)-1)
+}
+
+func newConnection(m1, m2 Matroid, p1, p2 Element) (*connection, error) {
+	if !m1.GroundSet().Contains(p1) {
+		return nil, fmt.Errorf("basepoint %s is not in the first GroundSet", p1.Key())
+	}
+	if !m2.GroundSet().Contains(p2) {
+		return nil, fmt.Errorf("basepoint %s is not in the second GroundSet", p2.Key())
+	}
+	return &connection{m1: m1, m2: m2, p1: p1, p2: p2}, nil
+}
+
+// ParallelConnection() returns the parallel connection of m1 and m2 with respect to the basepoints p1 and p2.
+// Its GroundSet consists of TaggedElements; p1 and p2 are identified into the basepoint tagged 0.
+// For graphic matroids this glues the graphs along the basepoint edges.
+func ParallelConnection(m1, m2 Matroid, p1, p2 Element) (Matroid, error) {
+	c, err := newConnection(m1, m2, p1, p2)
+	if err != nil {
+		return nil, err
+	}
+	return &rankMatroid{
+		groundSet: c.groundSet(),
+		rank:      c.rank,
+	}, nil
+}
+
+// SeriesConnection() returns the series connection of m1 and m2 with respect to the basepoints p1 and p2,
+// the dual of the parallel connection of the duals.
+func SeriesConnection(m1, m2 Matroid, p1, p2 Element) (Matroid, error) {
+	p, err := ParallelConnection(Dual(m1), Dual(m2), p1, p2)
+	if err != nil {
+		return nil, err
+	}
+	return Dual(p), nil
+}
+
+// TwoSum() returns the 2-sum of m1 and m2, the parallel connection with the basepoint deleted.
+// Neither basepoint may be a loop or a coloop.
+func TwoSum(m1, m2 Matroid, p1, p2 Element) (Matroid, error) {
+	for _, mp := range []struct {
+		m Matroid
+		p Element
+	}{{m1, p1}, {m2, p2}} {
+		if !mp.m.GroundSet().Contains(mp.p) {
+			continue
+		}
+		single := NewSet(mp.m.GroundSet().GetType(), mp.p)
+		rest := mp.m.GroundSet().Difference(single)
+		if mp.m.Rank(single) == 0 || mp.m.Rank(rest) < mp.m.Rank(mp.m.GroundSet()) {
+			return nil, errors.New("basepoints of a 2-sum must be neither loops nor coloops")
+		}
+	}
+	p, err := ParallelConnection(m1, m2, p1, p2)
+	if err != nil {
+		return nil, err
+	}
+	return Delete(p, NewSet(TaggedType, TaggedElement{Tag: 0, Element: p1})), nil
+}
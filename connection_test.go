@@ -0,0 +1,65 @@
+package matroid
+
+import (
+	"testing"
+)
+
+func TestParallelConnection(t *testing.T) {
+	// two triangles glued along arcs 2 and 12
+	g1 := NewGraphicMatroid(newTestDigraph(0, [2]int64{1, 2}, [2]int64{2, 3}, [2]int64{1, 3}))
+	g2 := NewGraphicMatroid(newTestDigraph(10, [2]int64{4, 5}, [2]int64{5, 6}, [2]int64{4, 6}))
+	glued := NewGraphicMatroid(newTestDigraph(0, [2]int64{1, 2}, [2]int64{2, 3}, [2]int64{1, 3}, [2]int64{1, 5}, [2]int64{5, 3}))
+	arc := func(g *GraphicMatroid, id int64) Element {
+		return g.GroundSet().Choose(func(e Element) bool { return e.(*Arc).Id == id })
+	}
+	iso := map[string]Element{
+		TaggedElement{Tag: 1, Element: arc(g1, 0)}.Key():  arc(glued, 0),
+		TaggedElement{Tag: 1, Element: arc(g1, 1)}.Key():  arc(glued, 1),
+		TaggedElement{Tag: 0, Element: arc(g1, 2)}.Key():  arc(glued, 2),
+		TaggedElement{Tag: 2, Element: arc(g2, 10)}.Key(): arc(glued, 3),
+		TaggedElement{Tag: 2, Element: arc(g2, 11)}.Key(): arc(glued, 4),
+	}
+
+	p, err := ParallelConnection(g1, g2, arc(g1, 2), arc(g2, 12))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !preservesRank(p, glued, iso) {
+		t.Errorf("parallel connection differs from the glued graph")
+	}
+
+	s, err := TwoSum(g1, g2, arc(g1, 2), arc(g2, 12))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the 2-sum of two triangles is a 4-cycle
+	if r := s.Rank(s.GroundSet()); r != 3 || s.GroundSet().Cardinality() != 4 {
+		t.Errorf("2-sum of triangles mismatch. expected: rank 3 on 4 elements, actual: rank %d on %d elements", r, s.GroundSet().Cardinality())
+	}
+
+	// the series connection of two triangles is a 5-cycle
+	sc, err := SeriesConnection(g1, g2, arc(g1, 2), arc(g2, 12))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := Isomorphic(sc, NewUniformMatroid(newTestSet(type1, 5), 4)); !ok {
+		t.Errorf("series connection of triangles is not a 5-circuit")
+	}
+}
+
+func TestParallelConnection_Loop(t *testing.T) {
+	// a loop as basepoint gives the direct sum with the contraction of the other matroid
+	loop := NewGraphicMatroid(newTestDigraph(0, [2]int64{1, 1}, [2]int64{1, 2}))
+	tri := NewGraphicMatroid(newTestDigraph(10, [2]int64{4, 5}, [2]int64{5, 6}, [2]int64{4, 6}))
+	p, err := ParallelConnection(loop, tri, loop.GroundSet().Choose(func(e Element) bool {
+		return e.(*Arc).Id == 0
+	}), tri.GroundSet().Choose(func(e Element) bool {
+		return e.(*Arc).Id == 12
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := p.Rank(p.GroundSet()); r != 2 {
+		t.Errorf("rank mismatch. expected: 2, actual: %d", r)
+	}
+}
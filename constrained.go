@@ -0,0 +1,120 @@
+package matroid
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ConstrainedBase() returns a base B of m of minimum total Weight() subject to |B ∩ r| = k,
+// as a minimum spanning tree with exactly k red arcs. It returns an error if there is no such base,
+// that is, unless r(E) - r(E \ r) <= k <= r(r).
+// Adding λ to the weights of the elements of r, the number of them in greedy minimum bases
+// decreases with λ. A breakpoint λ where it passes k is found by binary search over the differences
+// of weights, and a minimum base for it is exchanged towards k elements of r with ties only,
+// which keeps it optimal for the shifted weights and hence for the constrained problem.
+// Shifted weights within a relative tolerance of 1e-9 are treated as ties.
+func ConstrainedBase(m Matroid, r *Set, k int) (*Set, error) {
+	gs := m.GroundSet()
+	if r.GetType() != gs.GetType() || !r.IsSubsetOf(gs) {
+		return nil, errors.New("r must be a subset of the ground set")
+	}
+	rest := gs.Difference(r)
+	lo, hi := m.Rank(gs)-m.Rank(rest), m.Rank(r)
+	if k < lo || k > hi {
+		return nil, fmt.Errorf("no base has exactly %d elements of r: between %d and %d are possible", k, lo, hi)
+	}
+
+	red, others := sortedElements(r), sortedElements(rest)
+	// the values of λ where an element of r ties with another element
+	var breakpoints []float64
+	for _, x := range red {
+		for _, y := range others {
+			breakpoints = append(breakpoints, y.Weight()-x.Weight())
+		}
+	}
+	sort.Float64s(breakpoints)
+	var lambda float64
+	if len(breakpoints) > 0 {
+		// the first breakpoint where the greedy base preferring elements outside r has at most k of r
+		i := sort.Search(len(breakpoints), func(i int) bool {
+			return countIn(r, lagrangianBase(m, r, breakpoints[i], false)) <= k
+		})
+		if i == len(breakpoints) {
+			i--
+		}
+		lambda = breakpoints[i]
+	}
+
+	// preferring elements of r on ties gives at least k of them
+	b := lagrangianBase(m, r, lambda, true)
+	for count := countIn(r, b); count > k; count-- {
+		if !exchangeTie(m, b, red, others, lambda) {
+			return nil, errors.New("no tie exchange found due to rounding errors")
+		}
+	}
+	return b, nil
+}
+
+// lagrangianBase() returns a base of minimum weight for the weights of the elements of r increased
+// by lambda, preferring elements of r on ties if preferR.
+func lagrangianBase(m Matroid, r *Set, lambda float64, preferR bool) *Set {
+	elms := sortedElements(m.GroundSet())
+	inR := make(map[string]bool, len(elms))
+	for _, e := range elms {
+		inR[e.Key()] = r.Contains(e)
+	}
+	sort.SliceStable(elms, func(i, j int) bool {
+		a, b := elms[i], elms[j]
+		ra, rb := inR[a.Key()], inR[b.Key()]
+		if ra == rb {
+			return a.Weight() < b.Weight()
+		}
+		// compare the shifted weights as λ against a breakpoint
+		if ra {
+			d := b.Weight() - a.Weight()
+			if tied(lambda, d) {
+				return preferR
+			}
+			return lambda < d
+		}
+		d := a.Weight() - b.Weight()
+		if tied(lambda, d) {
+			return !preferR
+		}
+		return lambda > d
+	})
+	return greedyBase(m, elms)
+}
+
+// exchangeTie() replaces an element x of b in red by an element y outside b in others with the same
+// shifted weight, such that b stays a base. It returns false if there is no such pair.
+func exchangeTie(m Matroid, b *Set, red, others []Element, lambda float64) bool {
+	for _, x := range red {
+		if !b.Contains(x) {
+			continue
+		}
+		for _, y := range others {
+			if b.Contains(y) || !tied(lambda, y.Weight()-x.Weight()) {
+				continue
+			}
+			b.Swap(y, x)
+			if m.Independent(b) {
+				return true
+			}
+			b.Swap(x, y)
+		}
+	}
+	return false
+}
+
+// tied() returns true if λ equals the breakpoint d up to rounding errors, so that the shifted weights tie.
+// Weight differences that are equal in exact arithmetic may round differently.
+func tied(lambda, d float64) bool {
+	return math.Abs(lambda-d) <= submodularTolerance*math.Max(1, math.Abs(d))
+}
+
+func countIn(r, s *Set) int {
+	return s.Intersect(r).Cardinality()
+}
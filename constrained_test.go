@@ -0,0 +1,89 @@
+package matroid
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestConstrainedBase(t *testing.T) {
+	cases := []struct {
+		name   string
+		seed   int64
+		weight func(rnd *rand.Rand) float64
+	}{
+		// few distinct weights to make ties frequent
+		{"integral", 1, func(rnd *rand.Rand) float64 {
+			return float64(rnd.Intn(4))
+		}},
+		// differences such as 0.3 - 0.1 and 0.7 - 0.5 are equal but round differently
+		{"fractional", 2, func(rnd *rand.Rand) float64 {
+			return []float64{0.1, 0.3, 0.5, 0.7, 0.2, 0.6}[rnd.Intn(6)]
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rnd := rand.New(rand.NewSource(tc.seed))
+			for c := 0; c < 20; c++ {
+				var arcs [][2]int64
+				for i := 0; i < 9; i++ {
+					arcs = append(arcs, [2]int64{rnd.Int63n(5), rnd.Int63n(5)})
+				}
+				d := newTestDigraph(0, arcs...)
+				red := EmptySet(ArcType)
+				for _, e := range d.A.ToSlice() {
+					e.(*Arc).W = tc.weight(rnd)
+					if rnd.Intn(2) == 0 {
+						red.Add(e)
+					}
+				}
+				m := NewGraphicMatroid(d)
+				rank := m.Rank(m.GroundSet())
+				for k := 0; k <= rank; k++ {
+					// brute force over the bases
+					expected := math.Inf(1)
+					for _, s := range subsetsOf(m.GroundSet()) {
+						if s.Cardinality() == rank && countIn(red, s) == k && m.Independent(s) {
+							expected = math.Min(expected, totalWeight(s))
+						}
+					}
+					b, err := ConstrainedBase(m, red, k)
+					if math.IsInf(expected, 1) {
+						if err == nil {
+							t.Errorf("case %d k=%d: infeasible constraint is accepted", c, k)
+						}
+						continue
+					}
+					if err != nil {
+						t.Fatalf("case %d k=%d: %s", c, k, err)
+					}
+					if b.Cardinality() != rank || !m.Independent(b) {
+						t.Errorf("case %d k=%d: %v is not a base", c, k, b.ToSlice())
+					}
+					if n := countIn(red, b); n != k {
+						t.Errorf("case %d k=%d: count mismatch. expected: %d, actual: %d", c, k, k, n)
+					}
+					if w := totalWeight(b); math.Abs(w-expected) > 1e-9 {
+						t.Errorf("case %d k=%d: weight mismatch. expected: %g, actual: %g", c, k, expected, w)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestExchangeTie_Rounding(t *testing.T) {
+	// 0.3 - 0.1 and 0.7 - 0.5 differ in floating point
+	d := newTestDigraph(0, [2]int64{1, 2}, [2]int64{1, 2})
+	arcs := sortedElements(d.A)
+	x, y := arcs[0].(*Arc), arcs[1].(*Arc)
+	x.W, y.W = 0.1, 0.3
+	m := NewGraphicMatroid(d)
+	b := NewSet(ArcType, x)
+	if !exchangeTie(m, b, []Element{x}, []Element{y}, 0.7-0.5) {
+		t.Fatal("tie is not found")
+	}
+	if !b.Contains(y) || b.Contains(x) {
+		t.Errorf("exchange mismatch. expected: %v, actual: %v", []Element{y}, b.ToSlice())
+	}
+}
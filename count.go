@@ -0,0 +1,164 @@
+package matroid
+
+import (
+	"fmt"
+)
+
+// CountMatroid is the (k,l)-count matroid on the Arcs of a WeightedDigraph, with 0 <= l < 2k.
+// A set of Arcs is independent if every non-empty subset of it spans at least (|X|+l)/k vertices,
+// that is, if every subset X touching n vertices has |X| <= kn - l.
+// The (2,3)-count matroid is the generic rigidity matroid of the plane by Laman's theorem,
+// and the (k,k)-count matroid is the union of k graphic matroids.
+type CountMatroid struct {
+	groundSet *Set
+	k, l      int
+}
+
+func (c *CountMatroid) GroundSet() *Set {
+	return c.groundSet
+}
+
+// Rank() plays the pebble game on the Arcs of s in order of Key() and returns the number of accepted Arcs.
+func (c *CountMatroid) Rank(s *Set) int {
+	pg := newPebbleGame(c.k, c.l)
+	var r int
+	for _, e := range sortedElements(s) {
+		if pg.insert(e.(*Arc)) {
+			r++
+		}
+	}
+	return r
+}
+
+// Independent() returns false as soon as the pebble game rejects an Arc of s.
+func (c *CountMatroid) Independent(s *Set) bool {
+	pg := newPebbleGame(c.k, c.l)
+	for _, e := range sortedElements(s) {
+		if !pg.insert(e.(*Arc)) {
+			return false
+		}
+	}
+	return true
+}
+
+// NewCountMatroid() returns the (k,l)-count matroid of d. k must be positive and 0 <= l < 2k.
+// Loops are independent only for l < k.
+func NewCountMatroid(d *WeightedDigraph, k, l int) (*CountMatroid, error) {
+	if k < 1 || l < 0 || l >= 2*k {
+		return nil, fmt.Errorf("(%d,%d) is not a valid count; 0 <= l < 2k is required", k, l)
+	}
+	return &CountMatroid{
+		groundSet: d.A.Clone(),
+		k:         k,
+		l:         l,
+	}, nil
+}
+
+// NewRigidityMatroid() returns the generic rigidity matroid of d in the plane, the (2,3)-count matroid.
+// A set of Arcs is independent if the corresponding distance constraints of a generic framework are
+// not over-constrained.
+func NewRigidityMatroid(d *WeightedDigraph) *CountMatroid {
+	c, _ := NewCountMatroid(d, 2, 3)
+	return c
+}
+
+// pebbleGame is the pebble game of Lee and Streinu. Each vertex starts with k pebbles, and every accepted
+// Arc is covered by a pebble of one of its endpoints and directed away from it, so that each vertex
+// has k pebbles minus its out-degree.
+type pebbleGame struct {
+	k, l    int
+	pebbles map[int64]int
+	// out[v] holds the heads of the accepted arcs directed away from v
+	out map[int64][]int64
+}
+
+func newPebbleGame(k, l int) *pebbleGame {
+	return &pebbleGame{
+		k:       k,
+		l:       l,
+		pebbles: make(map[int64]int),
+		out:     make(map[int64][]int64),
+	}
+}
+
+func (pg *pebbleGame) addVertex(v int64) {
+	if _, ok := pg.pebbles[v]; !ok {
+		pg.pebbles[v] = pg.k
+	}
+}
+
+// insert() accepts a if l+1 pebbles can be gathered on its endpoints, and returns whether it was accepted.
+func (pg *pebbleGame) insert(a *Arc) bool {
+	u, v := a.Tail.Id, a.Head.Id
+	pg.addVertex(u)
+	pg.addVertex(v)
+	for pg.free(u, v) < pg.l+1 {
+		if !pg.gather(u, u, v) && (u == v || !pg.gather(v, u, v)) {
+			return false
+		}
+	}
+	if pg.pebbles[u] > 0 {
+		pg.pebbles[u]--
+		pg.out[u] = append(pg.out[u], v)
+	} else {
+		pg.pebbles[v]--
+		pg.out[v] = append(pg.out[v], u)
+	}
+	return true
+}
+
+// free() returns the number of pebbles on the endpoints u and v.
+func (pg *pebbleGame) free(u, v int64) int {
+	if u == v {
+		return pg.pebbles[u]
+	}
+	return pg.pebbles[u] + pg.pebbles[v]
+}
+
+// gather() searches along directed arcs from start for a vertex other than u and v holding a pebble,
+// and moves the pebble to start by reversing the path. It returns false if there is none.
+func (pg *pebbleGame) gather(start, u, v int64) bool {
+	prev := map[int64]int64{u: u, v: v}
+	if start != u && start != v {
+		prev[start] = start
+	}
+	stack := []int64{start}
+	found, target := false, start
+	for len(stack) > 0 && !found {
+		x := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, y := range pg.out[x] {
+			if _, ok := prev[y]; ok {
+				continue
+			}
+			prev[y] = x
+			if pg.pebbles[y] > 0 {
+				found, target = true, y
+				break
+			}
+			stack = append(stack, y)
+		}
+	}
+	if !found {
+		return false
+	}
+	pg.pebbles[target]--
+	for y := target; y != start; {
+		x := prev[y]
+		pg.reverse(x, y)
+		y = x
+	}
+	pg.pebbles[start]++
+	return true
+}
+
+// reverse() turns one arc x -> y into y -> x.
+func (pg *pebbleGame) reverse(x, y int64) {
+	for i, z := range pg.out[x] {
+		if z == y {
+			pg.out[x] = append(pg.out[x][:i], pg.out[x][i+1:]...)
+			break
+		}
+	}
+	pg.out[y] = append(pg.out[y], x)
+}
@@ -0,0 +1,83 @@
+package matroid
+
+import (
+	"testing"
+)
+
+func TestCountMatroid(t *testing.T) {
+	// K4 on 1, 2, 3, 4 with a second arc 1 -> 2 and a loop at 4
+	d := newTestDigraph(0, [2]int64{1, 2}, [2]int64{1, 3}, [2]int64{1, 4}, [2]int64{2, 3}, [2]int64{2, 4}, [2]int64{3, 4},
+		[2]int64{1, 2}, [2]int64{4, 4})
+	arcs := func(ids ...int64) *Set {
+		s := EmptySet(ArcType)
+		for _, id := range ids {
+			s.Add(d.A.Choose(func(e Element) bool { return e.(*Arc).Id == id }))
+		}
+		return s
+	}
+	count := func(k, l int) *CountMatroid {
+		c, err := NewCountMatroid(d, k, l)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	laman := NewRigidityMatroid(d)
+
+	cases := []struct {
+		name     string
+		m        Matroid
+		s        *Set
+		expected int
+	}{
+		{"laman triangle", laman, arcs(0, 1, 3), 3},
+		{"laman K4", laman, arcs(0, 1, 2, 3, 4, 5), 5},
+		{"laman double arc", laman, arcs(0, 6), 1},
+		{"laman loop", laman, arcs(7), 0},
+		{"laman ground set", laman, d.A, 5},
+		{"two forests K4", count(2, 2), arcs(0, 1, 2, 3, 4, 5), 6},
+		{"two forests ground set", count(2, 2), d.A, 6},
+		{"(2,1) loop", count(2, 1), arcs(7), 1},
+		{"(2,0) ground set", count(2, 0), d.A, 8},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := c.m.Rank(c.s); actual != c.expected {
+				t.Errorf("rank mismatch. expected: %d, actual: %d", c.expected, actual)
+			}
+		})
+	}
+
+	// (1,1) is the graphic matroid and (1,0) the bicircular matroid
+	graphic, bicircular := NewGraphicMatroid(d), NewBicircularMatroid(d)
+	forest, pseudoforest := count(1, 1), count(1, 0)
+	elms := sortedElements(d.A)
+	for mask := uint64(0); mask < 1<<uint(len(elms)); mask++ {
+		s := subsetOf(ArcType, elms, mask)
+		if expected, actual := graphic.Rank(s), forest.Rank(s); expected != actual {
+			t.Errorf("(1,1) rank mismatch for %b. expected: %d, actual: %d", mask, expected, actual)
+		}
+		if expected, actual := bicircular.Rank(s), pseudoforest.Rank(s); expected != actual {
+			t.Errorf("(1,0) rank mismatch for %b. expected: %d, actual: %d", mask, expected, actual)
+		}
+		// every non-empty subset of an independent set X satisfies |X| <= kn - l
+		for _, kl := range [][2]int{{2, 3}, {2, 1}, {3, 4}} {
+			expected := true
+			for sub := mask; sub != 0 && expected; sub = (sub - 1) & mask {
+				vertices := make(map[int64]bool)
+				for e := range subsetOf(ArcType, elms, sub).Iter() {
+					vertices[e.(*Arc).Tail.Id] = true
+					vertices[e.(*Arc).Head.Id] = true
+				}
+				expected = subsetOf(ArcType, elms, sub).Cardinality() <= kl[0]*len(vertices)-kl[1]
+			}
+			if actual := count(kl[0], kl[1]).Independent(s); expected != actual {
+				t.Errorf("(%d,%d) independence mismatch for %b. expected: %t, actual: %t", kl[0], kl[1], mask, expected, actual)
+			}
+		}
+	}
+
+	if _, err := NewCountMatroid(d, 2, 4); err == nil {
+		t.Error("expected an error for l >= 2k")
+	}
+}
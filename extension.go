@@ -0,0 +1,106 @@
+package matroid
+
+import "fmt"
+
+// rankMatroid is a matroid given by its GroundSet and rank function.
+type rankMatroid struct {
+	groundSet *Set
+	rank      func(*Set) int
+}
+
+func (rm *rankMatroid) GroundSet() *Set {
+	return rm.groundSet
+}
+
+func (rm *rankMatroid) Rank(s *Set) int {
+	return rm.rank(s)
+}
+
+func (rm *rankMatroid) Independent(s *Set) bool {
+	return s.Cardinality() == rm.Rank(s)
+}
+
+// Truncate() returns the truncation of m to rank k, whose rank function is min(r(X), k).
+// UniformMatroid is the truncation of a free matroid.
+// It panics if k is negative.
+func Truncate(m Matroid, k int) Matroid {
+	if k < 0 {
+		panic("k must be non-negative")
+	}
+	return &rankMatroid{
+		groundSet: m.GroundSet(),
+		rank: func(s *Set) int {
+			return min(m.Rank(s), k)
+		},
+	}
+}
+
+// Elongate() returns the elongation of m to rank k, the dual of the truncation of the dual of m.
+// Its rank function is min(|X|, r(X) + k - r(E)).
+// It panics unless k is between the rank of m and the size of the GroundSet.
+func Elongate(m Matroid, k int) Matroid {
+	n, r := m.GroundSet().Cardinality(), m.Rank(m.GroundSet())
+	if k < r || k > n {
+		panic(fmt.Sprintf("k must be between %d and %d", r, n))
+	}
+	return &rankMatroid{
+		groundSet: m.GroundSet(),
+		rank: func(s *Set) int {
+			return min(s.Cardinality(), m.Rank(s)+k-r)
+		},
+	}
+}
+
+// Lift() returns the Higgs lift of m, its elongation by one.
+// A matroid whose GroundSet is independent is returned as it is.
+func Lift(m Matroid) Matroid {
+	return Elongate(m, min(m.Rank(m.GroundSet())+1, m.GroundSet().Cardinality()))
+}
+
+// PrincipalExtension() returns the extension of m by a new element e placed freely on the flat spanned by f.
+// A set X containing e has rank r(X\{e}) if f is spanned by X\{e}, and r(X\{e}) + 1 otherwise.
+// f must be a subset of the GroundSet and e must not be in it.
+func PrincipalExtension(m Matroid, f *Set, e Element) (Matroid, error) {
+	gs := m.GroundSet()
+	if e.GetType() != gs.GetType() {
+		return nil, fmt.Errorf("ElementType mismatch: %s and %s", gs.GetType(), e.GetType())
+	}
+	if gs.Contains(e) {
+		return nil, fmt.Errorf("%s is already in the GroundSet", e.Key())
+	}
+	if !f.IsSubsetOf(gs) {
+		return nil, fmt.Errorf("flat is not a subset of the GroundSet")
+	}
+	groundSet := gs.Clone()
+	groundSet.Add(e)
+	return &rankMatroid{
+		groundSet: groundSet,
+		rank: func(s *Set) int {
+			if !s.Contains(e) {
+				return m.Rank(s)
+			}
+			s0 := s.Clone()
+			s0.Remove(e)
+			r := m.Rank(s0)
+			if m.Rank(s0.Union(f)) == r {
+				return r
+			}
+			return r + 1
+		},
+	}, nil
+}
+
+// FreeExtension() returns the extension of m by a new element e in general position,
+// that is, the principal extension on the whole GroundSet.
+func FreeExtension(m Matroid, e Element) (Matroid, error) {
+	return PrincipalExtension(m, m.GroundSet(), e)
+}
+
+// FreeCoextension() returns the dual of the free extension of the dual of m by e.
+func FreeCoextension(m Matroid, e Element) (Matroid, error) {
+	ext, err := FreeExtension(Dual(m), e)
+	if err != nil {
+		return nil, err
+	}
+	return Dual(ext), nil
+}
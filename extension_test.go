@@ -0,0 +1,75 @@
+package matroid
+
+import (
+	"testing"
+)
+
+func testMatroids(t *testing.T) map[string]Matroid {
+	return map[string]Matroid{
+		"U(2,5)": NewUniformMatroid(newTestSet(type1, 5), 2),
+		"F7": newTestGFMatroid(t, 2, [][]int{
+			{1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {1, 1, 0}, {1, 0, 1}, {0, 1, 1}, {1, 1, 1},
+		}),
+		"triangle, parallel pair and loop": newTestGFMatroid(t, 3, [][]int{
+			{1, 0, 0}, {0, 1, 0}, {1, 1, 0}, {0, 0, 1}, {0, 0, 2}, {0, 0, 0},
+		}),
+	}
+}
+
+func TestTruncateAndElongate(t *testing.T) {
+	for name, m := range testMatroids(t) {
+		t.Run(name, func(t *testing.T) {
+			n, r := m.GroundSet().Cardinality(), m.Rank(m.GroundSet())
+			for k := r; k <= n; k++ {
+				assertSameRanks(t, Truncate(Dual(m), n-k), Dual(Elongate(m, k)))
+			}
+			assertSameRanks(t, Lift(m), Dual(Truncate(Dual(m), n-r-1)))
+			assertSameRanks(t, Truncate(m, r), m)
+		})
+	}
+}
+
+func TestFreeExtension(t *testing.T) {
+	e := testElement1{V: 100}
+	for name, m := range testMatroids(t) {
+		t.Run(name, func(t *testing.T) {
+			ext, err := FreeExtension(m, e)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the truncation is obtained by contracting a free extension
+			r := m.Rank(m.GroundSet())
+			assertSameRanks(t, Truncate(m, r-1), Contract(ext, NewSet(type1, e)))
+			assertSameRanks(t, Delete(ext, NewSet(type1, e)), m)
+
+			coext, err := FreeCoextension(m, e)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c := coext.Rank(coext.GroundSet()); c != r+1 {
+				t.Errorf("rank of free coextension mismatch. expected: %d, actual: %d", r+1, c)
+			}
+			assertSameRanks(t, Contract(coext, NewSet(type1, e)), m)
+
+			if _, err := FreeExtension(m, testElement1{V: 0}); err == nil {
+				t.Errorf("FreeExtension() by an element of the GroundSet must fail")
+			}
+		})
+	}
+}
+
+func TestPrincipalExtension(t *testing.T) {
+	m := newTestGFMatroid(t, 2, [][]int{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}})
+	e := testElement1{V: 100}
+	// placing e on the line spanned by 0 and 1 makes {0, 1, e} a circuit
+	ext, err := PrincipalExtension(m, NewSet(type1, testElement1{V: 0}, testElement1{V: 1}), e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ext.Independent(NewSet(type1, testElement1{V: 0}, testElement1{V: 1}, e)) {
+		t.Errorf("{0, 1, e} must be dependent")
+	}
+	if !ext.Independent(NewSet(type1, testElement1{V: 0}, testElement1{V: 2}, e)) {
+		t.Errorf("{0, 2, e} must be independent")
+	}
+}
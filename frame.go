@@ -0,0 +1,171 @@
+package matroid
+
+// Gain is an element of a group labelling the arcs of a gain graph.
+// Traversing an arc from its Tail to its Head multiplies by its gain, and traversing it backwards by the inverse.
+type Gain interface {
+	// Mul() returns the product of the receiver and g, in this order.
+	Mul(g Gain) Gain
+	Inverse() Gain
+	IsIdentity() bool
+}
+
+// IntegerGain is the additive group of integers, the gain group of biased graphs
+// such as those arising from periodic frameworks.
+type IntegerGain int64
+
+func (i IntegerGain) Mul(g Gain) Gain {
+	return i + g.(IntegerGain)
+}
+
+func (i IntegerGain) Inverse() Gain {
+	return -i
+}
+
+func (i IntegerGain) IsIdentity() bool {
+	return i == 0
+}
+
+// FrameMatroid is the frame matroid of a gain graph whose underlying graph is a WeightedDigraph.
+// A cycle is balanced if the product of the gains around it is the identity.
+// A set of Arcs is independent if each of its connected components is a tree or contains exactly one cycle,
+// which is unbalanced.
+type FrameMatroid struct {
+	groundSet *Set
+	gain      func(*Arc) Gain
+}
+
+func (f *FrameMatroid) GroundSet() *Set {
+	return f.groundSet
+}
+
+// Rank() returns the number of vertices touched by s minus the number of balanced connected components it forms.
+func (f *FrameMatroid) Rank(s *Set) int {
+	return frameRank(s, f.balanced)
+}
+
+func (f *FrameMatroid) Independent(s *Set) bool {
+	return s.Cardinality() == f.Rank(s)
+}
+
+// balanced() returns true if every cycle of the connected arcs is balanced.
+// Potentials are assigned along a spanning tree, and each other arc must agree with them.
+func (f *FrameMatroid) balanced(arcs []*Arc) bool {
+	incident := make(map[int64][]*Arc)
+	for _, a := range arcs {
+		incident[a.Tail.Id] = append(incident[a.Tail.Id], a)
+		if a.Head.Id != a.Tail.Id {
+			incident[a.Head.Id] = append(incident[a.Head.Id], a)
+		}
+	}
+	root := arcs[0].Tail.Id
+	// the root gets the identity of the gain group
+	potential := map[int64]Gain{root: f.gain(arcs[0]).Mul(f.gain(arcs[0]).Inverse())}
+	queue := []int64{root}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, a := range incident[v] {
+			g := f.gain(a)
+			if a.Tail.Id == v {
+				if _, ok := potential[a.Head.Id]; !ok {
+					potential[a.Head.Id] = potential[v].Mul(g)
+					queue = append(queue, a.Head.Id)
+				}
+			} else if _, ok := potential[a.Tail.Id]; !ok {
+				potential[a.Tail.Id] = potential[v].Mul(g.Inverse())
+				queue = append(queue, a.Tail.Id)
+			}
+		}
+	}
+	for _, a := range arcs {
+		if !potential[a.Tail.Id].Mul(f.gain(a)).Mul(potential[a.Head.Id].Inverse()).IsIdentity() {
+			return false
+		}
+	}
+	return true
+}
+
+// NewFrameMatroid() returns the frame matroid of the gain graph d in which each Arc has gain gain(a).
+// gain must return values of the same group for every Arc of d.
+func NewFrameMatroid(d *WeightedDigraph, gain func(*Arc) Gain) *FrameMatroid {
+	return &FrameMatroid{
+		groundSet: d.A.Clone(),
+		gain:      gain,
+	}
+}
+
+// BicircularMatroid is the frame matroid of a graph in which every cycle, including loops, is unbalanced.
+// A set of Arcs is independent if each of its connected components contains at most one cycle,
+// that is, if it is a pseudoforest.
+type BicircularMatroid struct {
+	groundSet *Set
+}
+
+func (b *BicircularMatroid) GroundSet() *Set {
+	return b.groundSet
+}
+
+// Rank() returns the number of vertices touched by s minus the number of its connected components that are trees.
+func (b *BicircularMatroid) Rank(s *Set) int {
+	return frameRank(s, func(arcs []*Arc) bool {
+		vertices := make(map[int64]bool)
+		for _, a := range arcs {
+			vertices[a.Tail.Id] = true
+			vertices[a.Head.Id] = true
+		}
+		return len(arcs) == len(vertices)-1
+	})
+}
+
+func (b *BicircularMatroid) Independent(s *Set) bool {
+	return s.Cardinality() == b.Rank(s)
+}
+
+// NewBicircularMatroid() returns the bicircular matroid of d.
+// Arcs added to d afterwards are not part of the matroid.
+func NewBicircularMatroid(d *WeightedDigraph) *BicircularMatroid {
+	return &BicircularMatroid{
+		groundSet: d.A.Clone(),
+	}
+}
+
+// frameRank() splits the Arcs of s into connected components and returns the number of vertices
+// they touch minus the number of components for which balanced() returns true.
+func frameRank(s *Set, balanced func([]*Arc) bool) int {
+	parent := make(map[int64]int64)
+	var find func(int64) int64
+	find = func(v int64) int64 {
+		if p, ok := parent[v]; ok && p != v {
+			parent[v] = find(p)
+			return parent[v]
+		}
+		return v
+	}
+	arcs := make([]*Arc, 0, s.Cardinality())
+	vertices := make(map[int64]bool)
+	for _, e := range sortedElements(s) {
+		a := e.(*Arc)
+		arcs = append(arcs, a)
+		vertices[a.Tail.Id] = true
+		vertices[a.Head.Id] = true
+		if t, h := find(a.Tail.Id), find(a.Head.Id); t != h {
+			parent[t] = h
+		}
+	}
+	components := make(map[int64][]*Arc)
+	var roots []int64
+	for _, a := range arcs {
+		r := find(a.Tail.Id)
+		if _, ok := components[r]; !ok {
+			roots = append(roots, r)
+		}
+		components[r] = append(components[r], a)
+	}
+	r := len(vertices)
+	for _, root := range roots {
+		if balanced(components[root]) {
+			r--
+		}
+	}
+	return r
+}
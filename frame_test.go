@@ -0,0 +1,54 @@
+package matroid
+
+import (
+	"testing"
+)
+
+func TestFrameMatroid(t *testing.T) {
+	// a triangle 1, 2, 3 with a loop at 1, a parallel arc 2 -> 1 and a pendant arc 3 -> 4
+	d := newTestDigraph(0, [2]int64{1, 2}, [2]int64{2, 3}, [2]int64{1, 3}, [2]int64{1, 1}, [2]int64{2, 1}, [2]int64{3, 4})
+	arcs := func(ids ...int64) *Set {
+		s := EmptySet(ArcType)
+		for _, id := range ids {
+			s.Add(d.A.Choose(func(e Element) bool { return e.(*Arc).Id == id }))
+		}
+		return s
+	}
+	graphic := NewGraphicMatroid(d)
+	trivial := NewFrameMatroid(d, func(a *Arc) Gain { return IntegerGain(0) })
+	// the triangle and the digon are unbalanced, the loop is balanced
+	gains := map[int64]IntegerGain{0: 1, 1: 1, 2: 1, 3: 0, 4: 1, 5: 7}
+	gained := NewFrameMatroid(d, func(a *Arc) Gain { return gains[a.Id] })
+	bicircular := NewBicircularMatroid(d)
+
+	for _, s := range subsetsOf(d.A) {
+		if expected, actual := graphic.Rank(s), trivial.Rank(s); expected != actual {
+			t.Errorf("rank mismatch with trivial gains for %v. expected: %d, actual: %d", s.ToSlice(), expected, actual)
+		}
+	}
+
+	cases := []struct {
+		name     string
+		m        Matroid
+		s        *Set
+		expected int
+	}{
+		{"unbalanced triangle", gained, arcs(0, 1, 2), 3},
+		{"triangle with digon", gained, arcs(0, 1, 2, 4), 3},
+		// 1 -> 2 -> 1 has gain 1 + 1 = 2
+		{"unbalanced digon", gained, arcs(0, 4), 2},
+		{"balanced loop", gained, arcs(3), 0},
+		{"balanced loop on unbalanced cycle", gained, arcs(0, 1, 2, 3), 3},
+		{"bicircular loop", bicircular, arcs(3), 1},
+		{"bicircular pseudoforest", bicircular, arcs(0, 1, 2, 5), 4},
+		{"bicircular bicycle", bicircular, arcs(0, 1, 2, 3), 3},
+		{"bicircular ground set", bicircular, d.A, 4},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := c.m.Rank(c.s); actual != c.expected {
+				t.Errorf("rank mismatch. expected: %d, actual: %d", c.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package matroid
+
+import (
+	"errors"
+)
+
+// Gammoid is the matroid on a set of vertices of a WeightedDigraph in which a set is independent
+// if it is linked from the sources, that is, if it is the set of ends of vertex-disjoint directed paths
+// starting at sources. Paths may have length zero, so independent sources are linked by themselves.
+type Gammoid struct {
+	groundSet *Set
+	sources   []*Vertex
+	vertices  []*Vertex
+	// index of each vertex in vertices by Id
+	index map[int64]int
+	// heads of the arcs leaving each vertex, by index
+	out [][]int
+}
+
+func (g *Gammoid) GroundSet() *Set {
+	return g.groundSet
+}
+
+// Rank() returns the maximum number of vertex-disjoint paths from the sources to s.
+func (g *Gammoid) Rank(s *Set) int {
+	return len(g.Linking(s))
+}
+
+func (g *Gammoid) Independent(s *Set) bool {
+	return s.Cardinality() == g.Rank(s)
+}
+
+// NewGammoid() returns the gammoid on ground with respect to sources in d.
+// sources and ground must be sets of Vertices of d.
+func NewGammoid(d *WeightedDigraph, sources, ground *Set) (*Gammoid, error) {
+	if sources.GetType() != VertexType || ground.GetType() != VertexType {
+		return nil, errors.New("sources and ground must be sets of Vertices")
+	}
+	if !sources.IsSubsetOf(d.V) || !ground.IsSubsetOf(d.V) {
+		return nil, errors.New("sources and ground must be subsets of the vertices of the digraph")
+	}
+	g := &Gammoid{
+		groundSet: ground.Clone(),
+		index:     make(map[int64]int),
+	}
+	for _, e := range sortedElements(d.V) {
+		v := e.(*Vertex)
+		g.index[v.Id] = len(g.vertices)
+		g.vertices = append(g.vertices, v)
+	}
+	g.out = make([][]int, len(g.vertices))
+	for _, e := range sortedElements(d.A) {
+		a := e.(*Arc)
+		t, h := g.index[a.Tail.Id], g.index[a.Head.Id]
+		g.out[t] = append(g.out[t], h)
+	}
+	for _, e := range sortedElements(sources) {
+		g.sources = append(g.sources, e.(*Vertex))
+	}
+	return g, nil
+}
+
+// NewStrictGammoid() returns the strict gammoid, the gammoid on all vertices of d.
+func NewStrictGammoid(d *WeightedDigraph, sources *Set) (*Gammoid, error) {
+	return NewGammoid(d, sources, d.V)
+}
+
+// flowEdge is an edge of a residual network.
+type flowEdge struct {
+	to  int
+	cap int
+	// index of the reverse edge in the adjacency list of to
+	rev int
+	// true for edges of the original network
+	forward bool
+}
+
+type flowNetwork [][]flowEdge
+
+func (fn flowNetwork) addEdge(from, to, cap int) {
+	fn[from] = append(fn[from], flowEdge{to: to, cap: cap, rev: len(fn[to]), forward: true})
+	fn[to] = append(fn[to], flowEdge{to: from, cap: 0, rev: len(fn[from]) - 1})
+}
+
+// maxFlow() augments along shortest paths until the sink is unreachable and returns the flow value.
+func (fn flowNetwork) maxFlow(source, sink int) int {
+	var flow int
+	for {
+		prev := make([][2]int, len(fn))
+		for i := range prev {
+			prev[i] = [2]int{-1, -1}
+		}
+		prev[source] = [2]int{source, -1}
+		queue := []int{source}
+		for len(queue) > 0 && prev[sink][0] < 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for i, e := range fn[u] {
+				if e.cap > 0 && prev[e.to][0] < 0 {
+					prev[e.to] = [2]int{u, i}
+					queue = append(queue, e.to)
+				}
+			}
+		}
+		if prev[sink][0] < 0 {
+			return flow
+		}
+		// every capacity is 1, so one unit is pushed per path
+		for v := sink; v != source; v = prev[v][0] {
+			e := &fn[prev[v][0]][prev[v][1]]
+			e.cap--
+			fn[v][e.rev].cap++
+		}
+		flow++
+	}
+}
+
+// Linking() returns a maximum family of vertex-disjoint paths from the sources to vertices of s.
+// Each path is given as its sequence of vertices from a source to a vertex of s, and the number of
+// paths is the rank of s; s is independent if and only if every vertex of s ends some path.
+// s must be a subset of the GroundSet.
+func (g *Gammoid) Linking(s *Set) [][]*Vertex {
+	// vertex i is split into 2i -> 2i+1 with capacity 1
+	n := len(g.vertices)
+	source, sink := 2*n, 2*n+1
+	fn := make(flowNetwork, 2*n+2)
+	for i := range g.vertices {
+		fn.addEdge(2*i, 2*i+1, 1)
+		for _, j := range g.out[i] {
+			fn.addEdge(2*i+1, 2*j, 1)
+		}
+	}
+	for _, v := range g.sources {
+		fn.addEdge(source, 2*g.index[v.Id], 1)
+	}
+	for e := range s.Iter() {
+		fn.addEdge(2*g.index[e.(*Vertex).Id]+1, sink, 1)
+	}
+	fn.maxFlow(source, sink)
+
+	// decompose the flow into paths; vertex capacities make every walk from the source simple
+	var paths [][]*Vertex
+	for _, e := range fn[source] {
+		if !e.forward || e.cap > 0 {
+			continue
+		}
+		var path []*Vertex
+		for u := e.to; u != sink; {
+			path = append(path, g.vertices[u/2])
+			next := sink
+			for k, f := range fn[u+1] {
+				if f.forward && f.cap == 0 {
+					next = f.to
+					// consume the unit so that each edge is followed once
+					fn[u+1][k].cap = -1
+					break
+				}
+			}
+			u = next
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
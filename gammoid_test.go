@@ -0,0 +1,67 @@
+package matroid
+
+import (
+	"testing"
+)
+
+func TestGammoid(t *testing.T) {
+	// 1 -> 3 -> 5, 2 -> 3 -> 4, 2 -> 4: every path from {1, 2} to {4, 5} passes through 3 or 2 -> 4
+	d := newTestDigraph(0, [2]int64{1, 3}, [2]int64{2, 3}, [2]int64{3, 5}, [2]int64{3, 4}, [2]int64{2, 4})
+	vertices := func(ids ...int64) *Set {
+		s := EmptySet(VertexType)
+		for _, id := range ids {
+			s.Add(d.V.Choose(func(e Element) bool { return e.(*Vertex).Id == id }))
+		}
+		return s
+	}
+	g, err := NewGammoid(d, vertices(1, 2), vertices(3, 4, 5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	strict, err := NewStrictGammoid(d, vertices(1, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name     string
+		m        *Gammoid
+		s        *Set
+		expected int
+	}{
+		{"single vertex", g, vertices(5), 1},
+		{"linked pair", g, vertices(4, 5), 2},
+		{"bottleneck", g, vertices(3, 5), 1},
+		{"whole ground set", g, vertices(3, 4, 5), 2},
+		{"sources", strict, vertices(1, 2), 2},
+		{"source and sink", strict, vertices(1, 4), 2},
+		{"unreachable", strict, EmptySet(VertexType), 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			paths := c.m.Linking(c.s)
+			if len(paths) != c.expected {
+				t.Errorf("rank mismatch. expected: %d, actual: %d", c.expected, len(paths))
+			}
+			used := make(map[int64]bool)
+			for _, p := range paths {
+				if !vertices(1, 2).Contains(p[0]) || !c.s.Contains(p[len(p)-1]) {
+					t.Errorf("path %v does not link a source to the set", p)
+				}
+				for i, v := range p {
+					if used[v.Id] {
+						t.Errorf("vertex %d is used twice", v.Id)
+					}
+					used[v.Id] = true
+					if i > 0 && !d.HasEdgeFromTo(p[i-1].Id, v.Id) {
+						t.Errorf("no arc from %d to %d", p[i-1].Id, v.Id)
+					}
+				}
+			}
+		})
+	}
+
+	if _, err := NewGammoid(d, vertices(1), EmptySet(ArcType)); err == nil {
+		t.Error("expected an error for a ground set of Arcs")
+	}
+}
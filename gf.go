@@ -0,0 +1,162 @@
+package matroid
+
+import (
+	"errors"
+	"fmt"
+)
+
+// GFMatroid is a linear matroid over the prime field GF(P).
+// Each element of the GroundSet is represented by a column vector with entries in 0, ..., P-1.
+type GFMatroid struct {
+	P         int
+	groundSet *Set
+	columns   map[string][]int
+}
+
+func (g *GFMatroid) GroundSet() *Set {
+	return g.groundSet
+}
+
+func (g *GFMatroid) Rank(s *Set) int {
+	var vs [][]int
+	for e := range s.Iter() {
+		vs = append(vs, g.columns[e.Key()])
+	}
+	return rankGF(vs, g.P)
+}
+
+func (g *GFMatroid) Independent(s *Set) bool {
+	return s.Cardinality() == g.Rank(s)
+}
+
+// Column() returns the column vector representing e.
+// The returned slice must not be modified.
+func (g *GFMatroid) Column(e Element) []int {
+	return g.columns[e.Key()]
+}
+
+// NewGFMatroid() returns the linear matroid over GF(p) in which elms[i] is represented by columns[i].
+// p must be a prime, every column must have the same length, and entries are reduced modulo p.
+func NewGFMatroid(p int, elms []Element, columns [][]int) (*GFMatroid, error) {
+	if !isPrime(p) {
+		return nil, fmt.Errorf("%d is not a prime", p)
+	}
+	if len(elms) != len(columns) {
+		return nil, errors.New("the number of elements and columns differ")
+	}
+	if len(elms) == 0 {
+		return nil, errors.New("no elements given")
+	}
+	g := &GFMatroid{
+		P:         p,
+		groundSet: EmptySet(elms[0].GetType()),
+		columns:   make(map[string][]int),
+	}
+	for i, e := range elms {
+		if len(columns[i]) != len(columns[0]) {
+			return nil, errors.New("columns have different lengths")
+		}
+		if !g.groundSet.Add(e) {
+			return nil, fmt.Errorf("duplicate element %s", e.Key())
+		}
+		c := make([]int, len(columns[i]))
+		for j, v := range columns[i] {
+			c[j] = modGF(v, p)
+		}
+		g.columns[e.Key()] = c
+	}
+	return g, nil
+}
+
+func isPrime(p int) bool {
+	if p < 2 {
+		return false
+	}
+	for d := 2; d*d <= p; d++ {
+		if p%d == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func modGF(a, p int) int {
+	a %= p
+	if a < 0 {
+		a += p
+	}
+	return a
+}
+
+// inverseGF() returns the multiplicative inverse of a non-zero a modulo the prime p.
+func inverseGF(a, p int) int {
+	// Fermat's little theorem: a^(p-2) * a = 1
+	r, b, e := 1, modGF(a, p), p-2
+	for e > 0 {
+		if e&1 == 1 {
+			r = r * b % p
+		}
+		b = b * b % p
+		e >>= 1
+	}
+	return r
+}
+
+// eliminateGF() brings the matrix with the given columns into row echelon form over GF(p)
+// and returns the rank and the product of the pivots.
+// The input is not modified.
+func eliminateGF(vs [][]int, p int) (int, int) {
+	if len(vs) == 0 {
+		return 0, 1
+	}
+	// rows of a are the given column vectors; row rank equals column rank.
+	a := make([][]int, len(vs))
+	for i := range vs {
+		a[i] = append([]int(nil), vs[i]...)
+	}
+	rank, det := 0, 1
+	for c := 0; c < len(a[0]) && rank < len(a); c++ {
+		pivot := -1
+		for r := rank; r < len(a); r++ {
+			if a[r][c] != 0 {
+				pivot = r
+				break
+			}
+		}
+		if pivot < 0 {
+			continue
+		}
+		if pivot != rank {
+			a[pivot], a[rank] = a[rank], a[pivot]
+			det = modGF(-det, p)
+		}
+		det = det * a[rank][c] % p
+		inv := inverseGF(a[rank][c], p)
+		for r := rank + 1; r < len(a); r++ {
+			if a[r][c] == 0 {
+				continue
+			}
+			f := a[r][c] * inv % p
+			for k := c; k < len(a[r]); k++ {
+				a[r][k] = modGF(a[r][k]-f*a[rank][k], p)
+			}
+		}
+		rank++
+	}
+	return rank, det
+}
+
+// rankGF() returns the rank over GF(p) of the given vectors.
+func rankGF(vs [][]int, p int) int {
+	r, _ := eliminateGF(vs, p)
+	return r
+}
+
+// detGF() returns the determinant over GF(p) of the square matrix whose columns are vs.
+func detGF(vs [][]int, p int) int {
+	r, d := eliminateGF(vs, p)
+	if r < len(vs) {
+		return 0
+	}
+	return d
+}
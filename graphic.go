@@ -124,3 +124,47 @@ func NewWeightedDigraph() *WeightedDigraph {
 		V:                     EmptySet(VertexType),
 	}
 }
+
+// GraphicMatroid is the cycle matroid of the underlying undirected graph of a WeightedDigraph.
+// Its GroundSet is the set of Arcs, and a set of Arcs is independent if it contains no cycle.
+type GraphicMatroid struct {
+	groundSet *Set
+}
+
+func (g *GraphicMatroid) GroundSet() *Set {
+	return g.groundSet
+}
+
+// Rank() returns the number of vertices touched by s minus the number of connected components it forms.
+func (g *GraphicMatroid) Rank(s *Set) int {
+	parent := make(map[int64]int64)
+	var find func(int64) int64
+	find = func(v int64) int64 {
+		if p, ok := parent[v]; ok && p != v {
+			parent[v] = find(p)
+			return parent[v]
+		}
+		return v
+	}
+	var r int
+	for e := range s.Iter() {
+		a := e.(*Arc)
+		if t, h := find(a.Tail.Id), find(a.Head.Id); t != h {
+			parent[t] = h
+			r++
+		}
+	}
+	return r
+}
+
+func (g *GraphicMatroid) Independent(s *Set) bool {
+	return s.Cardinality() == g.Rank(s)
+}
+
+// NewGraphicMatroid() returns the cycle matroid of d.
+// Arcs added to d afterwards are not part of the matroid.
+func NewGraphicMatroid(d *WeightedDigraph) *GraphicMatroid {
+	return &GraphicMatroid{
+		groundSet: d.A.Clone(),
+	}
+}
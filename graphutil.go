@@ -3,7 +3,6 @@ package matroid
 import (
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/simple"
-	"gonum.org/v1/gonum/graph/traverse"
 )
 
 type node struct {
@@ -41,31 +40,38 @@ func (w *weightedEdge) Weight() float64 {
 	return w.head.element.Weight() - w.tail.element.Weight()
 }
 
-// findShortestPath() applies BFS to the input graph and return first found path from source to sink
-func findShortestPath(d *simple.WeightedDirectedGraph) []graph.Node {
-	paths := make(map[int64][]graph.Node)
-	var bfs traverse.BreadthFirst
-	var from graph.Node
-	bfs.Traverse = func(edge graph.Edge) bool {
-		from = edge.From()
-		return !bfs.Visited(edge.To())
-	}
-	bfs.Visit = func(i graph.Node) {
-		paths[i.ID()] = append(paths[from.ID()], i)
-	}
+// findShortestPath() applies BFS to the input graph from all sources at once and returns a shortest path
+// from a source to a sink, or nil if there is none. Such a path has no shortcuts, which matroid intersection
+// requires. It also returns the IDs of the nodes reachable from the sources.
+func findShortestPath(d *simple.WeightedDirectedGraph) ([]graph.Node, map[int64]bool) {
+	prev := make(map[int64]graph.Node)
+	reached := make(map[int64]bool)
+	var queue []graph.Node
 	it := d.Nodes()
 	for it.Next() {
-		if !it.Node().(*node).isSource {
-			continue
+		if it.Node().(*node).isSource {
+			reached[it.Node().ID()] = true
+			queue = append(queue, it.Node())
+		}
+	}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		if n.(*node).isSink {
+			path := []graph.Node{n}
+			for p, ok := prev[n.ID()]; ok; p, ok = prev[p.ID()] {
+				path = append([]graph.Node{p}, path...)
+			}
+			return path, reached
 		}
-		paths[it.Node().ID()] = []graph.Node{it.Node()}
-		end := bfs.Walk(d, it.Node(), func(n graph.Node, depth int) bool {
-			return n.(*node).isSink
-		})
-		if end != nil {
-			return paths[end.ID()]
+		to := d.From(n.ID())
+		for to.Next() {
+			if !reached[to.Node().ID()] {
+				reached[to.Node().ID()] = true
+				prev[to.Node().ID()] = n
+				queue = append(queue, to.Node())
+			}
 		}
-		paths = make(map[int64][]graph.Node)
 	}
-	return nil
+	return nil, reached
 }
@@ -0,0 +1,209 @@
+package matroid
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// subsetsOf() returns every subset of s, in order of bit masks over sortedElements(), for brute force.
+func subsetsOf(s *Set) []*Set {
+	elms := sortedElements(s)
+	subsets := make([]*Set, 0, 1<<uint(len(elms)))
+	for mask := uint64(0); mask < 1<<uint(len(elms)); mask++ {
+		subsets = append(subsets, subsetOf(s.GetType(), elms, mask))
+	}
+	return subsets
+}
+
+// newTestSet() returns a set of n test elements of type t.
+func newTestSet(t ElementType, n int) *Set {
+	s := EmptySet(t)
+	for i := 0; i < n; i++ {
+		if t == type1 {
+			s.Add(testElement1{V: i})
+		} else {
+			s.Add(testElement2{V: i})
+		}
+	}
+	return s
+}
+
+// newTestDigraph() returns a WeightedDigraph with arcs between the given pairs of vertices,
+// where the i-th arc has Id offset+i.
+func newTestDigraph(offset int64, arcs ...[2]int64) *WeightedDigraph {
+	d := NewWeightedDigraph()
+	vertices := make(map[int64]*Vertex)
+	vertex := func(id int64) *Vertex {
+		if _, ok := vertices[id]; !ok {
+			vertices[id] = &Vertex{Id: id}
+			d.AddVertex(vertices[id])
+		}
+		return vertices[id]
+	}
+	for i, a := range arcs {
+		d.AddArc(&Arc{Tail: vertex(a[0]), Head: vertex(a[1]), Id: offset + int64(i)})
+	}
+	return d
+}
+
+// newTestGFMatroid() returns the matroid of the given columns over GF(p) on test elements 0, 1, ....
+func newTestGFMatroid(t *testing.T, p int, columns [][]int) *GFMatroid {
+	var elms []Element
+	for i := range columns {
+		elms = append(elms, testElement1{V: i})
+	}
+	g, err := NewGFMatroid(p, elms, columns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+// intersectionTestCases() returns pairs of matroids on the same ground set:
+// partition matroids encoding bipartite matchings, and graphic matroids against partition matroids.
+func intersectionTestCases(t *testing.T) [][2]Matroid {
+	rnd := rand.New(rand.NewSource(1))
+	var cases [][2]Matroid
+	for k := 0; k < 10; k++ {
+		// arcs of a random bipartite graph between {0..3} and {10..13}, or of a random graph on {0..4}
+		d := NewWeightedDigraph()
+		vertices := make(map[int64]*Vertex)
+		vertex := func(id int64) *Vertex {
+			if _, ok := vertices[id]; !ok {
+				vertices[id] = &Vertex{Id: id}
+				d.AddVertex(vertices[id])
+			}
+			return vertices[id]
+		}
+		for i := 0; i < 9; i++ {
+			u, v := rnd.Int63n(4), 10+rnd.Int63n(4)
+			if k%2 == 1 {
+				u, v = rnd.Int63n(5), rnd.Int63n(5)
+			}
+			d.AddArc(&Arc{Tail: vertex(u), Head: vertex(v), Id: int64(i)})
+		}
+		// blocks by tail, and by head or by color
+		tails, heads := make(map[int64]*Set), make(map[int64]*Set)
+		for _, e := range d.A.ToSlice() {
+			a := e.(*Arc)
+			h := a.Head.Id
+			if k%2 == 1 {
+				h = a.Id % 3
+			}
+			for _, b := range []struct {
+				blocks map[int64]*Set
+				id     int64
+			}{{tails, a.Tail.Id}, {heads, h}} {
+				if _, ok := b.blocks[b.id]; !ok {
+					b.blocks[b.id] = EmptySet(ArcType)
+				}
+				b.blocks[b.id].Add(a)
+			}
+		}
+		partition := func(blocks map[int64]*Set) *PartitionMatroid {
+			var sets []*Set
+			for _, s := range blocks {
+				sets = append(sets, s)
+			}
+			m, err := NewPartitionMatroid(sets...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return m
+		}
+		if k%2 == 0 {
+			cases = append(cases, [2]Matroid{partition(tails), partition(heads)})
+		} else {
+			cases = append(cases, [2]Matroid{NewGraphicMatroid(d), partition(heads)})
+		}
+	}
+	return cases
+}
+
+// maxCommonIndependent() returns the size of a largest common independent set of ms by brute force.
+func maxCommonIndependent(ms ...Matroid) int {
+	var best int
+	for _, s := range subsetsOf(ms[0].GroundSet()) {
+		if s.Cardinality() > best && independentInAll(ms, s) {
+			best = s.Cardinality()
+		}
+	}
+	return best
+}
+
+// totalWeight() returns the sum of Weight() over s.
+func totalWeight(s *Set) float64 {
+	var w float64
+	for _, e := range s.ToSlice() {
+		w += e.Weight()
+	}
+	return w
+}
+
+// withinCaps() checks that s has at most caps[c] elements of each color c, and at most one of colors missing from caps.
+func withinCaps(s *Set, colorOf func(Element) string, caps map[string]int) bool {
+	count := make(map[string]int)
+	for _, e := range s.ToSlice() {
+		c := colorOf(e)
+		count[c]++
+		capacity, ok := caps[c]
+		if !ok {
+			capacity = 1
+		}
+		if count[c] > capacity {
+			return false
+		}
+	}
+	return true
+}
+
+// preservesRank() checks that iso maps every subset of m1's GroundSet onto a subset of m2's with equal rank.
+func preservesRank(m1, m2 Matroid, iso map[string]Element) bool {
+	for _, s := range subsetsOf(m1.GroundSet()) {
+		image := EmptySet(m2.GroundSet().GetType())
+		for _, e := range s.ToSlice() {
+			image.Add(iso[e.Key()])
+		}
+		if image.Cardinality() != s.Cardinality() || m1.Rank(s) != m2.Rank(image) {
+			return false
+		}
+	}
+	return true
+}
+
+// assertSameRanks() checks that m1 and m2 have the same GroundSet and rank function.
+func assertSameRanks(t *testing.T, m1, m2 Matroid) {
+	t.Helper()
+	if !m1.GroundSet().Equal(m2.GroundSet()) {
+		t.Fatalf("GroundSets differ: %v and %v", m1.GroundSet(), m2.GroundSet())
+	}
+	for _, s := range subsetsOf(m1.GroundSet()) {
+		if r1, r2 := m1.Rank(s), m2.Rank(s); r1 != r2 {
+			t.Fatalf("rank of %v mismatch: %d and %d", s, r1, r2)
+		}
+	}
+}
+
+// sameVector() compares two vectors keyed by Key() with the tolerance of submodular functions.
+func sameVector(x, y map[string]float64) bool {
+	for k, v := range x {
+		if math.Abs(v-y[k]) > submodularTolerance {
+			return false
+		}
+	}
+	for k, v := range y {
+		if math.Abs(v-x[k]) > submodularTolerance {
+			return false
+		}
+	}
+	return true
+}
+
+func vertexIds(s *Set) map[int64]bool {
+	ids := make(map[int64]bool)
+	for _, e := range s.ToSlice() {
+		ids[e.(*Vertex).Id] = true
+	}
+	return ids
+}
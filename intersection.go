@@ -0,0 +1,486 @@
+package matroid
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// IntersectionAlgorithm selects the augmenting path algorithm of IntersectionWith().
+type IntersectionAlgorithm int
+
+const (
+	// EdmondsIntersection builds the whole exchange digraph before each augmentation, as Intersection() does.
+	EdmondsIntersection IntersectionAlgorithm = iota
+	// CunninghamIntersection works in phases: each phase computes the distance layers of the exchange digraph
+	// once and augments along shortest paths through them until none is left, discovering arcs on demand.
+	CunninghamIntersection
+)
+
+// IntersectionOptions configures IntersectionWith().
+type IntersectionOptions struct {
+	Algorithm IntersectionAlgorithm
+	// BinarySearch makes CunninghamIntersection discover each arc of the exchange digraph by binary search
+	// over the candidate heads with rank oracle calls, as in the algorithm of Chakrabarty et al.,
+	// instead of testing the candidates one by one.
+	BinarySearch bool
+}
+
+// IntersectionResult is a maximum common independent set, the certificate of its optimality
+// as returned by IntersectionWithCertificate(), and statistics on the computation.
+type IntersectionResult struct {
+	Set         *Set
+	Certificate *Set
+	// RankCalls and IndependenceCalls count oracle calls to both matroids.
+	RankCalls         int
+	IndependenceCalls int
+	Augmentations     int
+	// Phases is the number of distance layerings computed by CunninghamIntersection.
+	Phases int
+}
+
+// IntersectionWith() returns a maximum common independent set of m1 and m2 computed as specified by opts.
+func IntersectionWith(m1, m2 Matroid, opts IntersectionOptions) (*IntersectionResult, error) {
+	return intersectionFrom(m1, m2, nil, opts)
+}
+
+// IntersectionFrom() returns a maximum common independent set of m1 and m2 obtained by augmenting start,
+// which must be a common independent set. Starting from a previous solution after a small change
+// takes only as many augmentations as the size of the solution grows.
+func IntersectionFrom(m1, m2 Matroid, start *Set) (*Set, error) {
+	if err := checkStart(m1, m2, start); err != nil {
+		return nil, err
+	}
+	r, err := intersectionFrom(m1, m2, start, IntersectionOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return r.Set, nil
+}
+
+func checkStart(m1, m2 Matroid, start *Set) error {
+	if err := checkIntersectable(m1, m2); err != nil {
+		return err
+	}
+	if start.GetType() != m1.GroundSet().GetType() || !start.IsSubsetOf(m1.GroundSet()) {
+		return errors.New("start must be a subset of the ground set")
+	}
+	if !m1.Independent(start) || !m2.Independent(start) {
+		return errors.New("start is not a common independent set")
+	}
+	return nil
+}
+
+// intersectionFrom() runs IntersectionWith() from the common independent set start,
+// or from a greedy one if start is nil.
+func intersectionFrom(m1, m2 Matroid, start *Set, opts IntersectionOptions) (*IntersectionResult, error) {
+	if err := checkIntersectable(m1, m2); err != nil {
+		return nil, err
+	}
+	c1, c2 := &countingMatroid{m: m1}, &countingMatroid{m: m2}
+	if start == nil {
+		start = greedyCommonIndependent(c1, c2)
+	}
+	result := &IntersectionResult{}
+	switch opts.Algorithm {
+	case CunninghamIntersection:
+		ci := newCunninghamIntersection(c1, c2, start, opts.BinarySearch)
+		result.Set, result.Certificate = ci.run()
+		result.Augmentations, result.Phases = ci.augmentations, ci.phases
+	default:
+		result.Set, result.Certificate, result.Augmentations = edmondsIntersection(c1, c2, start)
+	}
+	result.RankCalls = c1.rankCalls + c2.rankCalls
+	result.IndependenceCalls = c1.independenceCalls + c2.independenceCalls
+	return result, nil
+}
+
+// cunninghamIntersection holds the state of Cunningham's algorithm. Elements are indexed as elms,
+// and the exchange digraph has an arc y -> z if s - y + z is independent in m1,
+// and an arc z -> y if s - y + z is independent in m2, for y in s and z not in s.
+// Sources are the z with s + z independent in m1, and sinks those with s + z independent in m2.
+type cunninghamIntersection struct {
+	m1, m2 Matroid
+	elms   []Element
+	in     []bool
+	binary bool
+
+	augmentations int
+	phases        int
+}
+
+func newCunninghamIntersection(m1, m2 Matroid, start *Set, binary bool) *cunninghamIntersection {
+	ci := &cunninghamIntersection{
+		m1:     m1,
+		m2:     m2,
+		elms:   sortedElements(m1.GroundSet()),
+		binary: binary,
+	}
+	ci.in = make([]bool, len(ci.elms))
+	for i, e := range ci.elms {
+		ci.in[i] = start.Contains(e)
+	}
+	return ci
+}
+
+// set() returns the current common independent set with the elements of add added and those of remove removed.
+func (ci *cunninghamIntersection) set(add, remove []int) *Set {
+	s := EmptySet(ci.m1.GroundSet().GetType())
+	for i, e := range ci.elms {
+		if ci.in[i] {
+			s.Add(e)
+		}
+	}
+	for _, i := range remove {
+		s.Remove(ci.elms[i])
+	}
+	for _, i := range add {
+		s.Add(ci.elms[i])
+	}
+	return s
+}
+
+func (ci *cunninghamIntersection) size() int {
+	var n int
+	for _, in := range ci.in {
+		if in {
+			n++
+		}
+	}
+	return n
+}
+
+func (ci *cunninghamIntersection) isSource(z int) bool {
+	return !ci.in[z] && ci.m1.Independent(ci.set([]int{z}, nil))
+}
+
+func (ci *cunninghamIntersection) isSink(z int) bool {
+	return !ci.in[z] && ci.m2.Independent(ci.set([]int{z}, nil))
+}
+
+// hasArc() returns true if there is an arc from v to some element of candidates, all on the other side of v.
+func (ci *cunninghamIntersection) hasArc(v int, candidates []int) bool {
+	if ci.in[v] {
+		// s - v + Z spans s in m1 if and only if s - v + z is independent for some z in Z
+		return ci.m1.Rank(ci.set(candidates, []int{v})) >= ci.size()
+	}
+	// s + v contains a unique circuit of m2, and s - Y + v is independent if and only if Y meets it
+	return ci.m2.Independent(ci.set([]int{v}, candidates))
+}
+
+// findArc() returns the index in candidates of an element that v has an arc to, or -1.
+// Without binary search it is the first such element, so v has no arc to the ones before it.
+func (ci *cunninghamIntersection) findArc(v int, candidates []int) int {
+	if len(candidates) == 0 {
+		return -1
+	}
+	if !ci.binary {
+		for k, c := range candidates {
+			if ci.hasArc(v, []int{c}) {
+				return k
+			}
+		}
+		return -1
+	}
+	if !ci.hasArc(v, candidates) {
+		return -1
+	}
+	lo, hi := 0, len(candidates)
+	for hi-lo > 1 {
+		mid := (lo + hi) / 2
+		if ci.hasArc(v, candidates[lo:mid]) {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return lo
+}
+
+// layers() computes the distances from the sources by breadth-first search and returns them,
+// with -1 for unreachable elements, and the distance of the nearest sink, or -1 if no sink is reachable.
+func (ci *cunninghamIntersection) layers() ([]int, int) {
+	n := len(ci.elms)
+	dist := make([]int, n)
+	var queue []int
+	for i := range dist {
+		dist[i] = -1
+		if ci.isSource(i) {
+			dist[i] = 0
+			queue = append(queue, i)
+		}
+	}
+	sink := -1
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		if sink >= 0 && dist[v] >= sink {
+			continue
+		}
+		if !ci.in[v] && ci.isSink(v) {
+			sink = dist[v]
+			continue
+		}
+		var candidates []int
+		for i := range ci.elms {
+			if dist[i] < 0 && ci.in[i] != ci.in[v] {
+				candidates = append(candidates, i)
+			}
+		}
+		for {
+			k := ci.findArc(v, candidates)
+			if k < 0 {
+				break
+			}
+			w := candidates[k]
+			dist[w] = dist[v] + 1
+			queue = append(queue, w)
+			// without binary search the candidates before w have no arc from v and are dropped as well
+			if ci.binary {
+				candidates = append(candidates[:k], candidates[k+1:]...)
+			} else {
+				candidates = candidates[k+1:]
+			}
+		}
+	}
+	return dist, sink
+}
+
+// run() repeats phases until no sink is reachable and returns the result and its certificate.
+func (ci *cunninghamIntersection) run() (*Set, *Set) {
+	for {
+		dist, sink := ci.layers()
+		ci.phases++
+		if sink < 0 {
+			u := EmptySet(ci.m1.GroundSet().GetType())
+			for i, e := range ci.elms {
+				if dist[i] < 0 {
+					u.Add(e)
+				}
+			}
+			return ci.set(nil, nil), u
+		}
+		ci.augmentShortestPaths(dist, sink)
+	}
+}
+
+// augmentShortestPaths() searches paths through consecutive layers from sources to sinks in depth-first
+// manner, augmenting along each path found and removing its elements, as well as dead ends.
+// Arcs are tested against the current set, so every path found is a shortest augmenting path.
+func (ci *cunninghamIntersection) augmentShortestPaths(dist []int, sink int) {
+	alive := make([]bool, len(ci.elms))
+	for i, d := range dist {
+		alive[i] = d >= 0 && d <= sink
+	}
+	for r := range ci.elms {
+		if !alive[r] || dist[r] != 0 {
+			continue
+		}
+		if !ci.isSource(r) {
+			alive[r] = false
+			continue
+		}
+		path := []int{r}
+		for len(path) > 0 {
+			v := path[len(path)-1]
+			if dist[v] == sink {
+				if ci.isSink(v) {
+					for _, p := range path {
+						ci.in[p] = !ci.in[p]
+						alive[p] = false
+					}
+					ci.augmentations++
+					break
+				}
+				alive[v] = false
+				path = path[:len(path)-1]
+				continue
+			}
+			var candidates []int
+			for i := range ci.elms {
+				if alive[i] && dist[i] == dist[v]+1 {
+					candidates = append(candidates, i)
+				}
+			}
+			if k := ci.findArc(v, candidates); k >= 0 {
+				path = append(path, candidates[k])
+			} else {
+				alive[v] = false
+				path = path[:len(path)-1]
+			}
+		}
+	}
+}
+
+// IntersectionSolver maintains a maximum common independent set of two matroids restricted to
+// a set of active elements of their common GroundSet, which may change between calls of Solve().
+// After a change the previous solution, minus removed elements, is augmented instead of recomputed.
+type IntersectionSolver struct {
+	m1, m2 Matroid
+	opts   IntersectionOptions
+	// active elements of the GroundSet
+	active *Set
+	// current is a common independent set within active
+	current *Set
+}
+
+// NewIntersectionSolver() returns a solver for m1 and m2 with every element of the GroundSet active.
+func NewIntersectionSolver(m1, m2 Matroid, opts IntersectionOptions) (*IntersectionSolver, error) {
+	if err := checkIntersectable(m1, m2); err != nil {
+		return nil, err
+	}
+	return &IntersectionSolver{
+		m1:      m1,
+		m2:      m2,
+		opts:    opts,
+		active:  m1.GroundSet().Clone(),
+		current: EmptySet(m1.GroundSet().GetType()),
+	}, nil
+}
+
+// Add() activates e, an inactive element of the GroundSet.
+func (is *IntersectionSolver) Add(e Element) error {
+	if !is.m1.GroundSet().Contains(e) {
+		return fmt.Errorf("%s is not in the ground set", e.Key())
+	}
+	if !is.active.Add(e) {
+		return fmt.Errorf("%s is already active", e.Key())
+	}
+	return nil
+}
+
+// Remove() deactivates e, an active element, removing it from the current solution.
+func (is *IntersectionSolver) Remove(e Element) error {
+	if !is.active.Contains(e) {
+		return fmt.Errorf("%s is not active", e.Key())
+	}
+	is.active.Remove(e)
+	is.current.Remove(e)
+	return nil
+}
+
+// Active() returns the active elements. The returned Set must not be modified.
+func (is *IntersectionSolver) Active() *Set {
+	return is.active
+}
+
+// Solve() returns a maximum common independent set of the matroids restricted to the active elements,
+// with a certificate within the active elements.
+func (is *IntersectionSolver) Solve() (*IntersectionResult, error) {
+	inactive := is.m1.GroundSet().Difference(is.active)
+	m1, m2 := Delete(is.m1, inactive), Delete(is.m2, inactive)
+	r, err := intersectionFrom(m1, m2, is.current, is.opts)
+	if err != nil {
+		return nil, err
+	}
+	is.current = r.Set.Clone()
+	return r, nil
+}
+
+// WeightedIntersection() returns a common independent set of m1 and m2 of maximum cardinality
+// and, among those, of maximum total Weight().
+func WeightedIntersection(m1, m2 Matroid) (*Set, error) {
+	if err := checkIntersectable(m1, m2); err != nil {
+		return nil, err
+	}
+	return weightedIntersection(m1, m2, func(e Element) float64 {
+		return e.Weight()
+	}), nil
+}
+
+// weightedIntersectionTolerance is the tolerance for comparing path lengths in weightedIntersection().
+const weightedIntersectionTolerance = 1e-9
+
+// weightedIntersection() augments from the empty set along paths of the exchange digraph that are
+// shortest for the lengths w(y) of the elements y in s and -w(z) of the others, with fewest arcs among them.
+// After k augmentations s has maximum weight among common independent sets of size k.
+// Shortest paths are found by the Bellman-Ford algorithm, as lengths may be negative but cycles are not.
+func weightedIntersection(m1, m2 Matroid, w func(Element) float64) *Set {
+	elms := sortedElements(m1.GroundSet())
+	n := len(elms)
+	s := EmptySet(m1.GroundSet().GetType())
+	for {
+		in := make([]bool, n)
+		length := make([]float64, n)
+		for i, e := range elms {
+			in[i] = s.Contains(e)
+			length[i] = -w(e)
+			if in[i] {
+				length[i] = w(e)
+			}
+		}
+		// adj[v] lists the heads of the arcs from v
+		adj := make([][]int, n)
+		source, sink := make([]bool, n), make([]bool, n)
+		for z := range elms {
+			if in[z] {
+				continue
+			}
+			s.Add(elms[z])
+			source[z], sink[z] = m1.Independent(s), m2.Independent(s)
+			s.Remove(elms[z])
+			for y := range elms {
+				if !in[y] {
+					continue
+				}
+				s.Swap(elms[z], elms[y])
+				if m1.Independent(s) {
+					adj[y] = append(adj[y], z)
+				}
+				if m2.Independent(s) {
+					adj[z] = append(adj[z], y)
+				}
+				s.Swap(elms[y], elms[z])
+			}
+		}
+
+		dist, hops, pred := make([]float64, n), make([]int, n), make([]int, n)
+		for v := range elms {
+			dist[v], hops[v], pred[v] = math.Inf(1), 0, -1
+			if source[v] {
+				dist[v] = length[v]
+			}
+		}
+		shorter := func(d float64, h int, v int) bool {
+			if d < dist[v]-weightedIntersectionTolerance {
+				return true
+			}
+			return d <= dist[v]+weightedIntersectionTolerance && h < hops[v]
+		}
+		for round := 0; round < n; round++ {
+			changed := false
+			for v := range elms {
+				if math.IsInf(dist[v], 1) {
+					continue
+				}
+				for _, u := range adj[v] {
+					if shorter(dist[v]+length[u], hops[v]+1, u) {
+						dist[u], hops[u], pred[u] = dist[v]+length[u], hops[v]+1, v
+						changed = true
+					}
+				}
+			}
+			if !changed {
+				break
+			}
+		}
+
+		best := -1
+		for v := range elms {
+			if sink[v] && !math.IsInf(dist[v], 1) && (best < 0 || dist[v] < dist[best]-weightedIntersectionTolerance ||
+				dist[v] <= dist[best]+weightedIntersectionTolerance && hops[v] < hops[best]) {
+				best = v
+			}
+		}
+		if best < 0 {
+			return s
+		}
+		for v := best; v >= 0; v = pred[v] {
+			if in[v] {
+				s.Remove(elms[v])
+			} else {
+				s.Add(elms[v])
+			}
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package matroid
+
+import (
+	"testing"
+)
+
+func TestIntersectionWith(t *testing.T) {
+	options := []IntersectionOptions{
+		{Algorithm: EdmondsIntersection},
+		{Algorithm: CunninghamIntersection},
+		{Algorithm: CunninghamIntersection, BinarySearch: true},
+	}
+	for i, c := range intersectionTestCases(t) {
+		m1, m2 := c[0], c[1]
+		expected := maxCommonIndependent(m1, m2)
+		for _, opts := range options {
+			r, err := IntersectionWith(m1, m2, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !m1.Independent(r.Set) || !m2.Independent(r.Set) {
+				t.Errorf("case %d %+v: %v is not a common independent set", i, opts, r.Set.ToSlice())
+			}
+			if r.Set.Cardinality() != expected {
+				t.Errorf("case %d %+v: size mismatch. expected: %d, actual: %d", i, opts, expected, r.Set.Cardinality())
+			}
+			rest, _ := m1.GroundSet().Complement(r.Certificate)
+			if bound := m1.Rank(r.Certificate) + m2.Rank(rest); bound != expected {
+				t.Errorf("case %d %+v: certificate mismatch. expected: %d, actual: %d", i, opts, expected, bound)
+			}
+			if r.RankCalls+r.IndependenceCalls == 0 {
+				t.Errorf("case %d %+v: oracle calls are not counted", i, opts)
+			}
+		}
+	}
+}
+
+func TestIntersectionWith_Large(t *testing.T) {
+	// spanning forests of a grid with at most one arc of each of three colors per row
+	const n = 8
+	var arcs [][2]int64
+	for i := int64(0); i < n; i++ {
+		for j := int64(0); j < n; j++ {
+			if j+1 < n {
+				arcs = append(arcs, [2]int64{i*n + j, i*n + j + 1})
+			}
+			if i+1 < n {
+				arcs = append(arcs, [2]int64{i*n + j, (i+1)*n + j})
+			}
+		}
+	}
+	d := newTestDigraph(0, arcs...)
+	blocks := make(map[int64]*Set)
+	for _, e := range d.A.ToSlice() {
+		a := e.(*Arc)
+		k := a.Tail.Id/n*3 + a.Id%3
+		if _, ok := blocks[k]; !ok {
+			blocks[k] = EmptySet(ArcType)
+		}
+		blocks[k].Add(a)
+	}
+	var sets []*Set
+	for _, s := range blocks {
+		sets = append(sets, s)
+	}
+	pm, err := NewPartitionMatroid(sets...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewGraphicMatroid(d)
+
+	edmonds, err := IntersectionWith(g, pm, IntersectionOptions{Algorithm: EdmondsIntersection})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cunningham, err := IntersectionWith(g, pm, IntersectionOptions{Algorithm: CunninghamIntersection, BinarySearch: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if edmonds.Set.Cardinality() != cunningham.Set.Cardinality() {
+		t.Errorf("size mismatch. expected: %d, actual: %d", edmonds.Set.Cardinality(), cunningham.Set.Cardinality())
+	}
+	if e, c := edmonds.RankCalls+edmonds.IndependenceCalls, cunningham.RankCalls+cunningham.IndependenceCalls; c >= e {
+		t.Errorf("Cunningham's algorithm made %d oracle calls, not fewer than %d", c, e)
+	}
+}
+
+func TestIntersectionFrom(t *testing.T) {
+	for i, c := range intersectionTestCases(t) {
+		m1, m2 := c[0], c[1]
+		expected := maxCommonIndependent(m1, m2)
+		gs := m1.GroundSet()
+		// a single common independent element as well as the empty set
+		starts := []*Set{EmptySet(gs.GetType())}
+		for _, e := range sortedElements(gs) {
+			s := EmptySet(gs.GetType())
+			s.Add(e)
+			if m1.Independent(s) && m2.Independent(s) {
+				starts = append(starts, s)
+				break
+			}
+		}
+		for _, start := range starts {
+			s, err := IntersectionFrom(m1, m2, start)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !m1.Independent(s) || !m2.Independent(s) {
+				t.Errorf("case %d: %v is not a common independent set", i, s.ToSlice())
+			}
+			if s.Cardinality() != expected {
+				t.Errorf("case %d: size mismatch. expected: %d, actual: %d", i, expected, s.Cardinality())
+			}
+		}
+		if !m1.Independent(gs) || !m2.Independent(gs) {
+			if _, err := IntersectionFrom(m1, m2, gs); err == nil {
+				t.Errorf("case %d: dependent start is accepted", i)
+			}
+		}
+	}
+}
+
+func TestIntersectionSolver(t *testing.T) {
+	for i, c := range intersectionTestCases(t) {
+		m1, m2 := c[0], c[1]
+		is, err := NewIntersectionSolver(m1, m2, IntersectionOptions{Algorithm: CunninghamIntersection})
+		if err != nil {
+			t.Fatal(err)
+		}
+		check := func(step string) {
+			r, err := is.Solve()
+			if err != nil {
+				t.Fatal(err)
+			}
+			inactive := m1.GroundSet().Difference(is.Active())
+			d1, d2 := Delete(m1, inactive), Delete(m2, inactive)
+			expected := maxCommonIndependent(d1, d2)
+			if !r.Set.IsSubsetOf(is.Active()) || !d1.Independent(r.Set) || !d2.Independent(r.Set) {
+				t.Errorf("case %d %s: %v is not a common independent set", i, step, r.Set.ToSlice())
+			}
+			if r.Set.Cardinality() != expected {
+				t.Errorf("case %d %s: size mismatch. expected: %d, actual: %d", i, step, expected, r.Set.Cardinality())
+			}
+		}
+		check("initial")
+		elms := sortedElements(m1.GroundSet())
+		for j := 0; j < len(elms); j += 2 {
+			if err := is.Remove(elms[j]); err != nil {
+				t.Fatal(err)
+			}
+			check("remove " + elms[j].Key())
+		}
+		if err := is.Remove(elms[0]); err == nil {
+			t.Errorf("case %d: removing an inactive element is accepted", i)
+		}
+		for j := 0; j < len(elms); j += 2 {
+			if err := is.Add(elms[j]); err != nil {
+				t.Fatal(err)
+			}
+			check("add " + elms[j].Key())
+		}
+	}
+}
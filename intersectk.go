@@ -0,0 +1,150 @@
+package matroid
+
+import "errors"
+
+// intersectKSwaps is the largest number of elements removed by an improving swap of IntersectK().
+const intersectKSwaps = 2
+
+// IntersectK() returns a large common independent set of the input matroids by local search.
+// Starting from a maximal common independent set, it repeatedly replaces t <= intersectKSwaps elements
+// of the set by t+1 other elements while the result stays independent in every matroid.
+// For k matroids a local optimum is at least 2/(k+1) times the optimum already for swaps of one element
+// for two, and so for the default swap size; as the swap size grows the bound tends to 1/(k/2+ε)
+// (Lee, Sviridenko and Vondrák). Finding a maximum common independent set of three or
+// more matroids is NP-hard; see IntersectKExact() for small instances.
+func IntersectK(ms ...Matroid) (*Set, error) {
+	if err := checkIntersectableK(ms); err != nil {
+		return nil, err
+	}
+	return localSearchIntersection(ms), nil
+}
+
+// IntersectKExact() returns a maximum common independent set of the input matroids by branch and bound.
+// Its running time is exponential in the worst case, so it is meant for small ground sets.
+func IntersectKExact(ms ...Matroid) (*Set, error) {
+	if err := checkIntersectableK(ms); err != nil {
+		return nil, err
+	}
+	b := &intersectionBranch{
+		ms:   ms,
+		elms: sortedElements(ms[0].GroundSet()),
+		best: localSearchIntersection(ms),
+	}
+	b.search(0, EmptySet(ms[0].GroundSet().GetType()))
+	return b.best, nil
+}
+
+func checkIntersectableK(ms []Matroid) error {
+	if len(ms) == 0 {
+		return errors.New("no matroids to intersect")
+	}
+	for _, m := range ms[1:] {
+		if err := checkIntersectable(ms[0], m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func independentInAll(ms []Matroid, s *Set) bool {
+	for _, m := range ms {
+		if !m.Independent(s) {
+			return false
+		}
+	}
+	return true
+}
+
+func localSearchIntersection(ms []Matroid) *Set {
+	gs := ms[0].GroundSet()
+	s := EmptySet(gs.GetType())
+	for _, e := range sortedElements(gs) {
+		s.Add(e)
+		if !independentInAll(ms, s) {
+			s.Remove(e)
+		}
+	}
+	for improved := true; improved; {
+		improved = false
+		for t := 0; t <= intersectKSwaps && !improved; t++ {
+			improved = improveIntersection(ms, s, t)
+		}
+	}
+	return s
+}
+
+// improveIntersection() searches t elements of s and t+1 elements outside s whose swap keeps s
+// independent in every matroid, and performs it. It returns false if there is none.
+func improveIntersection(ms []Matroid, s *Set, t int) bool {
+	c, _ := ms[0].GroundSet().Complement(s)
+	in, out := sortedElements(s), sortedElements(c)
+	var next *Set
+	eachCombination(len(out), t+1, func(added []int) bool {
+		a := EmptySet(s.GetType())
+		for _, i := range added {
+			a.Add(out[i])
+		}
+		// subsets of independent sets are independent
+		if !independentInAll(ms, a) {
+			return true
+		}
+		eachCombination(len(in), t, func(removed []int) bool {
+			u := s.Union(a)
+			for _, i := range removed {
+				u.Remove(in[i])
+			}
+			if independentInAll(ms, u) {
+				next = u
+			}
+			return next == nil
+		})
+		return next == nil
+	})
+	if next == nil {
+		return false
+	}
+	for _, e := range in {
+		if !next.Contains(e) {
+			s.Remove(e)
+		}
+	}
+	for _, e := range out {
+		if next.Contains(e) {
+			s.Add(e)
+		}
+	}
+	return true
+}
+
+// intersectionBranch holds the state of the branch and bound of IntersectKExact().
+// Each element of elms is either included or excluded in turn, and a branch is cut when
+// min_i r_i(s ∪ rest) for the current set s and the undecided elements rest cannot beat best.
+type intersectionBranch struct {
+	ms   []Matroid
+	elms []Element
+	best *Set
+}
+
+func (b *intersectionBranch) search(i int, s *Set) {
+	if s.Cardinality() > b.best.Cardinality() {
+		b.best = s.Clone()
+	}
+	if i == len(b.elms) {
+		return
+	}
+	u := s.Clone()
+	for _, e := range b.elms[i:] {
+		u.Add(e)
+	}
+	for _, m := range b.ms {
+		if m.Rank(u) <= b.best.Cardinality() {
+			return
+		}
+	}
+	s.Add(b.elms[i])
+	if independentInAll(b.ms, s) {
+		b.search(i+1, s)
+	}
+	s.Remove(b.elms[i])
+	b.search(i+1, s)
+}
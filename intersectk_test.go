@@ -0,0 +1,54 @@
+package matroid
+
+import (
+	"testing"
+)
+
+func TestIntersectK(t *testing.T) {
+	for i, c := range intersectionTestCases(t) {
+		// a third matroid allowing one arc of each parity of Id
+		blocks := []*Set{EmptySet(ArcType), EmptySet(ArcType)}
+		for _, e := range c[0].GroundSet().ToSlice() {
+			blocks[e.(*Arc).Id%2].Add(e)
+		}
+		parity, err := NewPartitionMatroid(blocks...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, ms := range [][]Matroid{{c[0], c[1]}, {c[0], c[1], parity}} {
+			expected := maxCommonIndependent(ms...)
+			exact, err := IntersectKExact(ms...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !independentInAll(ms, exact) {
+				t.Errorf("case %d k=%d: %v is not a common independent set", i, len(ms), exact.ToSlice())
+			}
+			if exact.Cardinality() != expected {
+				t.Errorf("case %d k=%d: size mismatch. expected: %d, actual: %d", i, len(ms), expected, exact.Cardinality())
+			}
+			approx, err := IntersectK(ms...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !independentInAll(ms, approx) {
+				t.Errorf("case %d k=%d: %v is not a common independent set", i, len(ms), approx.ToSlice())
+			}
+			// a local optimum with swaps of up to intersectKSwaps elements for one more is at least 2/(k+1) times the optimum
+			if approx.Cardinality()*(len(ms)+1) < 2*expected {
+				t.Errorf("case %d k=%d: approximation too weak. optimum: %d, actual: %d", i, len(ms), expected, approx.Cardinality())
+			}
+		}
+	}
+}
+
+func TestIntersectK_Errors(t *testing.T) {
+	if _, err := IntersectK(); err == nil {
+		t.Errorf("no matroids are accepted")
+	}
+	m1 := NewUniformMatroid(newTestSet(type1, 3), 2)
+	m2 := NewUniformMatroid(newTestSet(type1, 4), 2)
+	if _, err := IntersectKExact(m1, m1, m2); err == nil {
+		t.Errorf("inequal ground sets are accepted")
+	}
+}
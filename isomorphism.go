@@ -0,0 +1,390 @@
+package matroid
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxIsomorphismSize is the largest ground set Isomorphic() and CanonicalForm() accept.
+// Subsets are encoded as bit masks of uint64.
+const maxIsomorphismSize = 64
+
+// profile is the structure of a matroid used for isomorphism testing.
+// Every field except elements is invariant under isomorphism.
+type profile struct {
+	elements []Element
+	rank     int
+	// independent[k] is the number of independent sets of size k
+	independent []int
+	// circuits are bit masks over elements
+	circuits []uint64
+	// flats[k] is the number of flats of rank k
+	flats []int
+	// signatures[i] describes how elements[i] lies in circuits and flats
+	signatures []string
+}
+
+// newProfile() enumerates independent sets, circuits and flats of m.
+// It takes one oracle call per independent set plus one per circuit.
+func newProfile(m Matroid) (*profile, error) {
+	elms := sortedElements(m.GroundSet())
+	n := len(elms)
+	if n > maxIsomorphismSize {
+		return nil, fmt.Errorf("ground set has %d elements; at most %d are supported", n, maxIsomorphismSize)
+	}
+	t := m.GroundSet().GetType()
+	p := &profile{elements: elms}
+
+	// independent sets are closed under taking subsets, so every independent set
+	// is reached by adding its elements in increasing order.
+	indep := map[uint64]bool{0: true}
+	sets := []uint64{0}
+	for i := 0; i < len(sets); i++ {
+		for j := bits.Len64(sets[i]); j < n; j++ {
+			s := sets[i] | 1<<uint(j)
+			if m.Independent(subsetOf(t, elms, s)) {
+				indep[s] = true
+				sets = append(sets, s)
+			}
+		}
+	}
+
+	p.independent = make([]int, n+1)
+	for _, s := range sets {
+		k := bits.OnesCount64(s)
+		p.independent[k]++
+		p.rank = max(p.rank, k)
+	}
+	p.independent = p.independent[:p.rank+1]
+
+	// a circuit minus its largest element is independent.
+	for _, s := range sets {
+		for j := bits.Len64(s); j < n; j++ {
+			c := s | 1<<uint(j)
+			if indep[c] || !isCircuitMask(c, indep) {
+				continue
+			}
+			p.circuits = append(p.circuits, c)
+		}
+	}
+
+	// the closure of each independent set is a flat of rank |I|.
+	flats := make([]map[uint64]bool, p.rank+1)
+	for k := range flats {
+		flats[k] = make(map[uint64]bool)
+	}
+	for _, s := range sets {
+		f := s
+		for j := 0; j < n; j++ {
+			if s&(1<<uint(j)) == 0 && !indep[s|1<<uint(j)] {
+				f |= 1 << uint(j)
+			}
+		}
+		flats[bits.OnesCount64(s)][f] = true
+	}
+	p.flats = make([]int, p.rank+1)
+	for k := range flats {
+		p.flats[k] = len(flats[k])
+	}
+
+	p.signatures = make([]string, n)
+	for i := range elms {
+		circuitSizes := make([]int, n+1)
+		for _, c := range p.circuits {
+			if c&(1<<uint(i)) != 0 {
+				circuitSizes[bits.OnesCount64(c)]++
+			}
+		}
+		flatRanks := make([]int, p.rank+1)
+		for k := range flats {
+			for f := range flats[k] {
+				if f&(1<<uint(i)) != 0 {
+					flatRanks[k]++
+				}
+			}
+		}
+		p.signatures[i] = fmt.Sprint(circuitSizes, flatRanks)
+	}
+	return p, nil
+}
+
+// isCircuitMask() returns true if every proper subset of c obtained by removing one element is independent.
+func isCircuitMask(c uint64, indep map[uint64]bool) bool {
+	for r := c; r != 0; r &= r - 1 {
+		if !indep[c&^(r&-r)] {
+			return false
+		}
+	}
+	return true
+}
+
+// sameInvariants() compares the isomorphism invariants of two profiles.
+func (p *profile) sameInvariants(q *profile) bool {
+	if len(p.elements) != len(q.elements) || p.rank != q.rank || len(p.circuits) != len(q.circuits) {
+		return false
+	}
+	if fmt.Sprint(p.independent, p.flats) != fmt.Sprint(q.independent, q.flats) {
+		return false
+	}
+	s0 := append([]string(nil), p.signatures...)
+	s1 := append([]string(nil), q.signatures...)
+	sort.Strings(s0)
+	sort.Strings(s1)
+	return strings.Join(s0, ";") == strings.Join(s1, ";")
+}
+
+// canonicalSearch finds the ordering of elements whose circuit encoding is lexicographically smallest.
+// It refines ordered partitions of the elements by their position in circuits and individualizes
+// elements when refinement stalls. Automorphisms found on the way prune equivalent branches.
+type canonicalSearch struct {
+	p        *profile
+	incident [][]int
+	best     []uint64
+	order    []int
+	autos    [][]int
+}
+
+// canonicalOrder() returns the canonical ordering of the elements of p and its circuit encoding.
+func canonicalOrder(p *profile) ([]int, []uint64) {
+	n := len(p.elements)
+	cs := &canonicalSearch{p: p, incident: make([][]int, n)}
+	for ci, c := range p.circuits {
+		for r := c; r != 0; r &= r - 1 {
+			i := bits.TrailingZeros64(r)
+			cs.incident[i] = append(cs.incident[i], ci)
+		}
+	}
+
+	// initial cells group elements by their signature
+	bySig := make(map[string][]int)
+	var sigs []string
+	for i, s := range p.signatures {
+		if _, ok := bySig[s]; !ok {
+			sigs = append(sigs, s)
+		}
+		bySig[s] = append(bySig[s], i)
+	}
+	sort.Strings(sigs)
+	var cells [][]int
+	for _, s := range sigs {
+		cells = append(cells, bySig[s])
+	}
+	cs.search(cells, nil)
+	return cs.order, cs.best
+}
+
+func (cs *canonicalSearch) search(cells [][]int, prefix []int) {
+	cells = cs.refine(cells)
+	target := -1
+	for i, c := range cells {
+		if len(c) > 1 {
+			target = i
+			break
+		}
+	}
+	if target < 0 {
+		cs.leaf(cells)
+		return
+	}
+	var explored []int
+	for _, x := range cells[target] {
+		if cs.inExploredOrbit(x, explored, prefix) {
+			continue
+		}
+		rest := make([]int, 0, len(cells[target])-1)
+		for _, y := range cells[target] {
+			if y != x {
+				rest = append(rest, y)
+			}
+		}
+		next := make([][]int, 0, len(cells)+1)
+		next = append(next, cells[:target]...)
+		next = append(next, []int{x}, rest)
+		next = append(next, cells[target+1:]...)
+		cs.search(next, append(prefix[:len(prefix):len(prefix)], x))
+		explored = append(explored, x)
+	}
+}
+
+// refine() splits cells by the cells met by the circuits through each element until nothing changes.
+func (cs *canonicalSearch) refine(cells [][]int) [][]int {
+	cellOf := make([]int, len(cs.p.elements))
+	for {
+		for ci, c := range cells {
+			for _, e := range c {
+				cellOf[e] = ci
+			}
+		}
+		var next [][]int
+		for _, c := range cells {
+			if len(c) == 1 {
+				next = append(next, c)
+				continue
+			}
+			sig := make(map[int]string, len(c))
+			for _, e := range c {
+				var cc []string
+				for _, ci := range cs.incident[e] {
+					var others []int
+					for r := cs.p.circuits[ci] &^ (1 << uint(e)); r != 0; r &= r - 1 {
+						others = append(others, cellOf[bits.TrailingZeros64(r)])
+					}
+					sort.Ints(others)
+					cc = append(cc, fmt.Sprint(others))
+				}
+				sort.Strings(cc)
+				sig[e] = strings.Join(cc, ";")
+			}
+			split := append([]int(nil), c...)
+			sort.SliceStable(split, func(i, j int) bool {
+				return sig[split[i]] < sig[split[j]]
+			})
+			start := 0
+			for i := 1; i <= len(split); i++ {
+				if i == len(split) || sig[split[i]] != sig[split[start]] {
+					next = append(next, split[start:i])
+					start = i
+				}
+			}
+		}
+		if len(next) == len(cells) {
+			return next
+		}
+		cells = next
+	}
+}
+
+// leaf() compares the encoding of a discrete partition with the best one found so far.
+func (cs *canonicalSearch) leaf(cells [][]int) {
+	order := make([]int, len(cells))
+	pos := make([]int, len(cells))
+	for i, c := range cells {
+		order[i] = c[0]
+		pos[c[0]] = i
+	}
+	enc := make([]uint64, len(cs.p.circuits))
+	for i, c := range cs.p.circuits {
+		for r := c; r != 0; r &= r - 1 {
+			enc[i] |= 1 << uint(pos[bits.TrailingZeros64(r)])
+		}
+	}
+	sort.Slice(enc, func(i, j int) bool { return enc[i] < enc[j] })
+
+	switch compareEncodings(enc, cs.best) {
+	case -1:
+		cs.best, cs.order = enc, order
+	case 0:
+		auto := make([]int, len(order))
+		for i := range order {
+			auto[order[i]] = cs.order[i]
+		}
+		cs.autos = append(cs.autos, auto)
+	}
+}
+
+// inExploredOrbit() returns true if x is mapped onto an explored element by an automorphism
+// found so far that fixes every element of prefix.
+func (cs *canonicalSearch) inExploredOrbit(x int, explored, prefix []int) bool {
+	if len(explored) == 0 {
+		return false
+	}
+	parent := make([]int, len(cs.p.elements))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for _, a := range cs.autos {
+		fixes := true
+		for _, e := range prefix {
+			if a[e] != e {
+				fixes = false
+				break
+			}
+		}
+		if !fixes {
+			continue
+		}
+		for i, j := range a {
+			parent[find(i)] = find(j)
+		}
+	}
+	for _, e := range explored {
+		if find(e) == find(x) {
+			return true
+		}
+	}
+	return false
+}
+
+// compareEncodings() compares a and b lexicographically. A nil b is greater than anything.
+func compareEncodings(a, b []uint64) int {
+	if b == nil {
+		return -1
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// Isomorphic() returns a bijection from the Key() of each element of m1's GroundSet to an element
+// of m2's GroundSet mapping circuits onto circuits, and false if m1 and m2 are not isomorphic.
+// Invariants (rank profile, circuit sizes, flat counts) reject most non-isomorphic pairs before the search.
+// The running time grows exponentially with the size of the GroundSets.
+func Isomorphic(m1, m2 Matroid) (map[string]Element, bool, error) {
+	p1, err := newProfile(m1)
+	if err != nil {
+		return nil, false, err
+	}
+	p2, err := newProfile(m2)
+	if err != nil {
+		return nil, false, err
+	}
+	if !p1.sameInvariants(p2) {
+		return nil, false, nil
+	}
+	o1, enc1 := canonicalOrder(p1)
+	o2, enc2 := canonicalOrder(p2)
+	if compareEncodings(enc1, enc2) != 0 {
+		return nil, false, nil
+	}
+	iso := make(map[string]Element, len(o1))
+	for i := range o1 {
+		iso[p1.elements[o1[i]].Key()] = p2.elements[o2[i]]
+	}
+	return iso, true, nil
+}
+
+// CanonicalForm() returns a string that is equal for two matroids if and only if they are isomorphic,
+// up to hash collisions. It is suitable as a map key to deduplicate matroids.
+func CanonicalForm(m Matroid) (string, error) {
+	p, err := newProfile(m)
+	if err != nil {
+		return "", err
+	}
+	_, enc := canonicalOrder(p)
+	var sb strings.Builder
+	sb.WriteString(strconv.Itoa(len(p.elements)))
+	for _, c := range enc {
+		sb.WriteString(",")
+		sb.WriteString(strconv.FormatUint(c, 16))
+	}
+	sum := sha256.Sum256([]byte(sb.String()))
+	return hex.EncodeToString(sum[:]), nil
+}
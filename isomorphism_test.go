@@ -0,0 +1,86 @@
+package matroid
+
+import (
+	"testing"
+)
+
+func TestIsomorphic(t *testing.T) {
+	tests := []struct {
+		name string
+		m1   Matroid
+		m2   Matroid
+		want bool
+	}{
+		{
+			name: "uniform matroids on different ElementTypes",
+			m1:   NewUniformMatroid(newTestSet(type1, 5), 2),
+			m2:   NewUniformMatroid(newTestSet(type2, 5), 2),
+			want: true,
+		},
+		{
+			name: "uniform matroids of different rank",
+			m1:   NewUniformMatroid(newTestSet(type1, 4), 2),
+			m2:   NewUniformMatroid(newTestSet(type2, 4), 1),
+			want: false,
+		},
+		{
+			name: "U(2,4) is self dual",
+			m1:   Dual(NewUniformMatroid(newTestSet(type1, 4), 2)),
+			m2:   NewUniformMatroid(newTestSet(type2, 4), 2),
+			want: true,
+		},
+		{
+			name: "scaled vectors",
+			m1: NewLinearMatroid(Matrix{
+				NewUnweightedVector([]float64{1, 0, 0}),
+				NewUnweightedVector([]float64{0, 1, 0}),
+				NewUnweightedVector([]float64{1, 1, 0}),
+				NewUnweightedVector([]float64{0, 0, 1}),
+				NewUnweightedVector([]float64{1, 0, 1}),
+			}),
+			m2: NewLinearMatroid(Matrix{
+				NewUnweightedVector([]float64{0, 0, 3}),
+				NewUnweightedVector([]float64{2, 0, 2}),
+				NewUnweightedVector([]float64{0, 2, 0}),
+				NewUnweightedVector([]float64{-1, 0, 0}),
+				NewUnweightedVector([]float64{1, 1, 0}),
+			}),
+			want: true,
+		},
+		{
+			name: "triangle and a coloop against two parallel pairs and a coloop",
+			m1: NewLinearMatroid(Matrix{
+				NewUnweightedVector([]float64{1, 0, 0}),
+				NewUnweightedVector([]float64{0, 1, 0}),
+				NewUnweightedVector([]float64{1, 1, 0}),
+				NewUnweightedVector([]float64{0, 0, 1}),
+			}),
+			m2: NewLinearMatroid(Matrix{
+				NewUnweightedVector([]float64{1, 0, 0}),
+				NewUnweightedVector([]float64{2, 0, 0}),
+				NewUnweightedVector([]float64{0, 1, 0}),
+				NewUnweightedVector([]float64{0, 0, 1}),
+			}),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iso, ok, err := Isomorphic(tt.m1, tt.m2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != tt.want {
+				t.Fatalf("Isomorphic() = %v, want %v", ok, tt.want)
+			}
+			if ok && !preservesRank(tt.m1, tt.m2, iso) {
+				t.Errorf("Isomorphic() returned a bijection that does not preserve rank: %v", iso)
+			}
+			c1, _ := CanonicalForm(tt.m1)
+			c2, _ := CanonicalForm(tt.m2)
+			if (c1 == c2) != tt.want {
+				t.Errorf("CanonicalForm() equality = %v, want %v", c1 == c2, tt.want)
+			}
+		})
+	}
+}
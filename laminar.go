@@ -0,0 +1,90 @@
+package matroid
+
+import (
+	"errors"
+	"sort"
+)
+
+// LaminarMatroid is the matroid in which a set is independent if it meets each set of a laminar family
+// in at most the capacity of that set. A family is laminar if any two of its sets are disjoint or nested.
+// The GroundSet is the union of the family.
+type LaminarMatroid struct {
+	groundSet *Set
+	// family sorted by cardinality, so that every set comes before the sets containing it
+	family []Partition
+	// parent[i] is the index of the smallest set containing family[i], or -1
+	parent []int
+	// deepest[e.Key()] is the index of the smallest set containing e
+	deepest map[string]int
+}
+
+func (l *LaminarMatroid) GroundSet() *Set {
+	return l.groundSet
+}
+
+// Rank() computes the rank of s from the leaves of the laminar tree upward:
+// the rank within a set is its own elements in s plus the ranks within its children, cut by its capacity.
+func (l *LaminarMatroid) Rank(s *Set) int {
+	ranks := make([]int, len(l.family))
+	for e := range s.Iter() {
+		ranks[l.deepest[e.Key()]]++
+	}
+	var r int
+	for i, p := range l.family {
+		ranks[i] = min(ranks[i], p.n)
+		if l.parent[i] < 0 {
+			r += ranks[i]
+		} else {
+			ranks[l.parent[i]] += ranks[i]
+		}
+	}
+	return r
+}
+
+func (l *LaminarMatroid) Independent(s *Set) bool {
+	return s.Cardinality() == l.Rank(s)
+}
+
+// NewLaminarMatroid() returns the laminar matroid of the given sets and capacities.
+// The sets must form a laminar family and capacities must be non-negative.
+// A partition into blocks is a laminar family, so this generalizes NewGeneralizedPartitionMatroid().
+func NewLaminarMatroid(p []Partition) (*LaminarMatroid, error) {
+	if len(p) == 0 {
+		return nil, errors.New("no sets given")
+	}
+	family := append([]Partition(nil), p...)
+	sort.SliceStable(family, func(i, j int) bool {
+		return family[i].set.Cardinality() < family[j].set.Cardinality()
+	})
+	l := &LaminarMatroid{
+		groundSet: EmptySet(family[0].set.GetType()),
+		family:    family,
+		parent:    make([]int, len(family)),
+		deepest:   make(map[string]int),
+	}
+	for i, pi := range family {
+		if pi.set.GetType() != l.groundSet.GetType() {
+			return nil, errors.New("sets have different types")
+		}
+		if pi.n < 0 {
+			return nil, errors.New("capacities must be non-negative")
+		}
+		l.parent[i] = -1
+		for j := i + 1; j < len(family); j++ {
+			pj := family[j]
+			if pi.set.IsSubsetOf(pj.set) {
+				l.parent[i] = j
+				break
+			}
+			if !pi.set.Intersect(pj.set).IsEmpty() {
+				return nil, errors.New("given sets are not laminar")
+			}
+		}
+		for _, e := range pi.set.ToSlice() {
+			if l.groundSet.Add(e) {
+				l.deepest[e.Key()] = i
+			}
+		}
+	}
+	return l, nil
+}
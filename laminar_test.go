@@ -0,0 +1,67 @@
+package matroid
+
+import (
+	"testing"
+)
+
+func TestLaminarMatroid(t *testing.T) {
+	gs := newTestSet(type1, 6)
+	elms := sortedElements(gs)
+	block := func(idx ...int) *Set {
+		s := EmptySet(type1)
+		for _, i := range idx {
+			s.Add(elms[i])
+		}
+		return s
+	}
+	// regions {0, 1} and {2, 3} in a country {0, ..., 4}, and element 5 in the global set
+	l, err := NewLaminarMatroid([]Partition{
+		{set: gs, n: 3},
+		{set: block(0, 1, 2, 3, 4), n: 2},
+		{set: block(0, 1), n: 1},
+		{set: block(2, 3), n: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		name     string
+		s        *Set
+		expected int
+	}{
+		{"empty", block(), 0},
+		{"region", block(0, 1), 1},
+		{"two regions", block(0, 2), 2},
+		{"country", block(0, 2, 4), 2},
+		{"global", block(0, 2, 5), 3},
+		{"everything", gs, 3},
+		{"free element", block(4, 5), 2},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := l.Rank(c.s); actual != c.expected {
+				t.Errorf("rank mismatch. expected: %d, actual: %d", c.expected, actual)
+			}
+		})
+	}
+
+	// a partition is a laminar family
+	partition := []Partition{{set: block(0, 1, 2), n: 2}, {set: block(3, 4, 5), n: 1}}
+	l, err = NewLaminarMatroid(partition)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pm, err := NewGeneralizedPartitionMatroid(partition)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range subsetsOf(gs) {
+		if expected, actual := pm.Rank(s), l.Rank(s); expected != actual {
+			t.Errorf("rank mismatch for %v. expected: %d, actual: %d", s.ToSlice(), expected, actual)
+		}
+	}
+
+	if _, err := NewLaminarMatroid([]Partition{{set: block(0, 1), n: 1}, {set: block(1, 2), n: 1}}); err == nil {
+		t.Error("expected an error for crossing sets")
+	}
+}
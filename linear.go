@@ -18,6 +18,9 @@ func (l *LinearMatroid) GroundSet() *Set {
 }
 
 func (l *LinearMatroid) Rank(s *Set) int {
+	if s.IsEmpty() {
+		return 0
+	}
 	return rank(l.GetMatrixOf(s), 0)
 }
 
@@ -78,7 +81,7 @@ func (m Matrix) Dims() (r, c int) {
 }
 
 func (m Matrix) T() mat.Matrix {
-	return mat.Transpose{m}
+	return mat.Transpose{Matrix: m}
 }
 
 // Each Vector of the input Matrix will be an element of the GroundSet.
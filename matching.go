@@ -0,0 +1,201 @@
+package matroid
+
+// MatchingMatroid is the matroid on the vertices of the underlying undirected graph of a WeightedDigraph
+// in which a set is independent if some matching of the graph covers all of its vertices.
+// With vertex weights W, GetMaximumWeightBaseOf() returns a set of vertices of maximum weight coverable by a matching.
+type MatchingMatroid struct {
+	groundSet *Set
+	vertices  []*Vertex
+	// index of each vertex in vertices by Id
+	index map[int64]int
+	// neighbours of each vertex by index, without loops
+	adj [][]int
+	// an arc joining each pair of adjacent vertices by index, smaller index first
+	arcs map[[2]int]*Arc
+}
+
+func (m *MatchingMatroid) GroundSet() *Set {
+	return m.groundSet
+}
+
+// Rank() returns the maximum number of vertices of s covered by a matching.
+func (m *MatchingMatroid) Rank(s *Set) int {
+	var r int
+	for e := range m.Matching(s).Iter() {
+		a := e.(*Arc)
+		if s.Contains(a.Tail) {
+			r++
+		}
+		if s.Contains(a.Head) {
+			r++
+		}
+	}
+	return r
+}
+
+func (m *MatchingMatroid) Independent(s *Set) bool {
+	return s.Cardinality() == m.Rank(s)
+}
+
+// Matching() returns a set of Arcs forming a matching that covers as many vertices of s as possible,
+// so that the number of vertices of s it covers is the rank of s.
+// A matching covering the most vertices of s is found as a maximum matching of the graph made of
+// two copies of the graph in which the two copies of each vertex outside s are joined:
+// a maximum matching leaves exactly rank(s) vertices of s covered in each copy.
+func (m *MatchingMatroid) Matching(s *Set) *Set {
+	n := len(m.vertices)
+	adj := make([][]int, 2*n)
+	for i, ns := range m.adj {
+		for _, j := range ns {
+			adj[i] = append(adj[i], j)
+			adj[n+i] = append(adj[n+i], n+j)
+		}
+	}
+	for i, v := range m.vertices {
+		if !s.Contains(v) {
+			adj[i] = append(adj[i], n+i)
+			adj[n+i] = append(adj[n+i], i)
+		}
+	}
+	match := maximumMatching(adj)
+
+	matching := EmptySet(ArcType)
+	for i := 0; i < n; i++ {
+		if j := match[i]; j > i && j < n {
+			matching.Add(m.arcs[[2]int{i, j}])
+		}
+	}
+	return matching
+}
+
+// NewMatchingMatroid() returns the matching matroid of d.
+// Vertices and Arcs added to d afterwards are not part of the matroid.
+func NewMatchingMatroid(d *WeightedDigraph) *MatchingMatroid {
+	m := &MatchingMatroid{
+		groundSet: d.V.Clone(),
+		index:     make(map[int64]int),
+		arcs:      make(map[[2]int]*Arc),
+	}
+	for _, e := range sortedElements(d.V) {
+		v := e.(*Vertex)
+		m.index[v.Id] = len(m.vertices)
+		m.vertices = append(m.vertices, v)
+	}
+	m.adj = make([][]int, len(m.vertices))
+	for _, e := range sortedElements(d.A) {
+		a := e.(*Arc)
+		i, j := m.index[a.Tail.Id], m.index[a.Head.Id]
+		if i == j {
+			continue
+		}
+		if i > j {
+			i, j = j, i
+		}
+		if _, ok := m.arcs[[2]int{i, j}]; ok {
+			continue
+		}
+		m.arcs[[2]int{i, j}] = a
+		m.adj[i] = append(m.adj[i], j)
+		m.adj[j] = append(m.adj[j], i)
+	}
+	return m
+}
+
+// maximumMatching() returns a maximum matching of the undirected graph with the given adjacency lists
+// by Edmonds' blossom algorithm, as the mate of each vertex or -1.
+func maximumMatching(adj [][]int) []int {
+	n := len(adj)
+	match := make([]int, n)
+	for i := range match {
+		match[i] = -1
+	}
+	parent := make([]int, n)
+	base := make([]int, n)
+	used := make([]bool, n)
+	blossom := make([]bool, n)
+
+	// lca() returns the base of the blossom closed by an edge between a and b
+	lca := func(a, b int) int {
+		seen := make([]bool, n)
+		for {
+			a = base[a]
+			seen[a] = true
+			if match[a] < 0 {
+				break
+			}
+			a = parent[match[a]]
+		}
+		for {
+			b = base[b]
+			if seen[b] {
+				return b
+			}
+			b = parent[match[b]]
+		}
+	}
+	markPath := func(v, b, child int) {
+		for base[v] != b {
+			blossom[base[v]], blossom[base[match[v]]] = true, true
+			parent[v] = child
+			child = match[v]
+			v = parent[match[v]]
+		}
+	}
+	// findPath() returns the end of an augmenting path from root, or -1
+	findPath := func(root int) int {
+		for i := range used {
+			used[i] = false
+			parent[i] = -1
+			base[i] = i
+		}
+		used[root] = true
+		queue := []int{root}
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			for _, to := range adj[v] {
+				if base[v] == base[to] || match[v] == to {
+					continue
+				}
+				if to == root || match[to] >= 0 && parent[match[to]] >= 0 {
+					b := lca(v, to)
+					for i := range blossom {
+						blossom[i] = false
+					}
+					markPath(v, b, to)
+					markPath(to, b, v)
+					for i := 0; i < n; i++ {
+						if blossom[base[i]] {
+							base[i] = b
+							if !used[i] {
+								used[i] = true
+								queue = append(queue, i)
+							}
+						}
+					}
+				} else if parent[to] < 0 {
+					parent[to] = v
+					if match[to] < 0 {
+						return to
+					}
+					used[match[to]] = true
+					queue = append(queue, match[to])
+				}
+			}
+		}
+		return -1
+	}
+
+	for root := 0; root < n; root++ {
+		if match[root] >= 0 {
+			continue
+		}
+		for v := findPath(root); v >= 0; {
+			pv := parent[v]
+			next := match[pv]
+			match[v], match[pv] = pv, v
+			v = next
+		}
+	}
+	return match
+}
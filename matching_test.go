@@ -0,0 +1,73 @@
+package matroid
+
+import (
+	"testing"
+)
+
+func TestMatchingMatroid(t *testing.T) {
+	// a pentagon 1, ..., 5 with a triangle 5, 6, 7 attached, a pendant vertex 8 at 2 and an isolated loop at 9
+	d := newTestDigraph(0, [2]int64{1, 2}, [2]int64{2, 3}, [2]int64{3, 4}, [2]int64{4, 5}, [2]int64{5, 1},
+		[2]int64{5, 6}, [2]int64{6, 7}, [2]int64{7, 5}, [2]int64{8, 2}, [2]int64{9, 9})
+	m := NewMatchingMatroid(d)
+	vertices := sortedElements(d.V)
+
+	// brute force over all sets of arcs forming a matching
+	var matchings []map[int64]bool
+	for _, arcs := range subsetsOf(d.A) {
+		covered := make(map[int64]bool)
+		ok := true
+		for _, e := range sortedElements(arcs) {
+			a := e.(*Arc)
+			if a.Tail.Id == a.Head.Id || covered[a.Tail.Id] || covered[a.Head.Id] {
+				ok = false
+				break
+			}
+			covered[a.Tail.Id], covered[a.Head.Id] = true, true
+		}
+		if ok {
+			matchings = append(matchings, covered)
+		}
+	}
+	for _, s := range subsetsOf(d.V) {
+		expected := 0
+		for _, covered := range matchings {
+			var c int
+			for _, v := range s.ToSlice() {
+				if covered[v.(*Vertex).Id] {
+					c++
+				}
+			}
+			expected = max(expected, c)
+		}
+		if actual := m.Rank(s); expected != actual {
+			t.Errorf("rank mismatch for %v. expected: %d, actual: %d", s.ToSlice(), expected, actual)
+		}
+	}
+
+	// the heaviest vertices coverable by a matching
+	for _, e := range vertices {
+		e.(*Vertex).W = float64(e.(*Vertex).Id)
+	}
+	base := GetMaximumWeightBaseOf(m)
+	var w float64
+	for e := range base.Iter() {
+		w += e.Weight()
+	}
+	// 8-2, 3-4, 5-1 and 6-7 cover every vertex but the loop
+	if expected := float64(1 + 2 + 3 + 4 + 5 + 6 + 7 + 8); w != expected {
+		t.Errorf("weight mismatch. expected: %f, actual: %f", expected, w)
+	}
+	if c := m.Matching(base).Cardinality(); c != 4 {
+		t.Errorf("matching size mismatch. expected: %d, actual: %d", 4, c)
+	}
+
+	// in a star only the center and one leaf can be covered, so the heaviest leaf must be chosen
+	star := NewMatchingMatroid(newTestDigraph(0, [2]int64{1, 2}, [2]int64{1, 3}, [2]int64{1, 4}))
+	for _, e := range star.GroundSet().ToSlice() {
+		e.(*Vertex).W = float64(e.(*Vertex).Id)
+	}
+	base = GetMaximumWeightBaseOf(star)
+	if ids := vertexIds(base); len(ids) != 2 || !ids[1] || !ids[4] {
+		t.Errorf("base mismatch. expected: [1 4], actual: %v", ids)
+	}
+}
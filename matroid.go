@@ -39,33 +39,69 @@ func (s sorter) Swap(i, j int) {
 
 // Intersection() returns maximal matroid intersection of input two matroids.
 func Intersection(m1, m2 Matroid) (*Set, error) {
+	s, _, err := IntersectionWithCertificate(m1, m2)
+	return s, err
+}
+
+// IntersectionWithCertificate() returns a maximum common independent set I of input two matroids
+// together with a set U proving its optimality by Edmonds' min-max theorem: |I| = r1(U) + r2(E\U).
+// U consists of the elements not reachable from the sources in the final exchange digraph.
+func IntersectionWithCertificate(m1, m2 Matroid) (*Set, *Set, error) {
+	if err := checkIntersectable(m1, m2); err != nil {
+		return nil, nil, err
+	}
+	s, u, _ := edmondsIntersection(m1, m2, greedyCommonIndependent(m1, m2))
+	return s, u, nil
+}
+
+func checkIntersectable(m1, m2 Matroid) error {
 	if !(m1.GroundSet().GetType() == m2.GroundSet().GetType()) {
-		return nil, fmt.Errorf("incomparable setTypes: %s and %s",
+		return fmt.Errorf("incomparable setTypes: %s and %s",
 			m1.GroundSet().GetType(), m2.GroundSet().GetType())
 	}
 	if !m1.GroundSet().Equal(m2.GroundSet()) {
-		return nil, fmt.Errorf("inequal GroundSets")
+		return fmt.Errorf("inequal GroundSets")
 	}
+	return nil
+}
+
+// greedyCommonIndependent() returns a maximal common independent set found by adding elements
+// one by one in order of Key().
+func greedyCommonIndependent(m1, m2 Matroid) *Set {
 	gs := m1.GroundSet()
 	s := EmptySet(gs.GetType())
-
-	for e := range gs.Iter() {
+	for _, e := range sortedElements(gs) {
 		s.Add(e)
 		if !(m1.Independent(s) && m2.Independent(s)) {
 			s.Remove(e)
 		}
 	}
+	return s
+}
 
+// edmondsIntersection() augments the common independent set s along shortest paths of the exchange digraph,
+// rebuilt after every augmentation, and returns the result, the certificate and the number of augmentations.
+func edmondsIntersection(m1, m2 Matroid, s *Set) (*Set, *Set, int) {
+	gs := m1.GroundSet()
+	s = s.Clone()
+	var augmentations int
 	for {
 		c, _ := gs.Complement(s)
 		d := generateMatroidIntersectionBipartiteDigraph(s, c, m1, m2)
-		p := findShortestPath(d)
+		p, reached := findShortestPath(d)
 		if p == nil {
-			break
+			u := EmptySet(gs.GetType())
+			it := d.Nodes()
+			for it.Next() {
+				if !reached[it.Node().ID()] {
+					u.Add(it.Node().(*node).element)
+				}
+			}
+			return s, u, augmentations
 		}
 		swapAlongPath(s, p)
+		augmentations++
 	}
-	return s, nil
 }
 
 func generateMatroidIntersectionBipartiteDigraph(s, c *Set, m1, m2 Matroid) *simple.WeightedDirectedGraph {
@@ -79,10 +115,10 @@ func generateMatroidIntersectionBipartiteDigraph(s, c *Set, m1, m2 Matroid) *sim
 	for f := range c.Iter() {
 		s0.Add(f)
 		if m1.Independent(s0) {
-			nodes[f.Key()].isSink = true
+			nodes[f.Key()].isSource = true
 		}
 		if m2.Independent(s0) {
-			nodes[f.Key()].isSource = true
+			nodes[f.Key()].isSink = true
 		}
 		s0.Remove(f)
 	}
@@ -128,24 +164,29 @@ func getKeyToNodeMap(s, c *Set) map[string]*node {
 
 // GetBaseOf() returns an arbitrary base of input matroid.
 func GetBaseOf(m Matroid) *Set {
-	set := EmptySet(m.GroundSet().GetType())
-
-	s := m.GroundSet().ToSlice()
-	for i := 0; i < len(s); i++ {
-		set.Add(s[i])
-		if !m.Independent(set) {
-			set.Remove(s[i])
-		}
-	}
-	return set
+	return greedyBase(m, m.GroundSet().ToSlice())
 }
 
 // GetMaximalBaseOf() returns maximal base of input matroid.
 func GetMaximalBaseOf(m Matroid) *Set {
-	set := EmptySet(m.GroundSet().GetType())
 	var s sorter
 	s = m.GroundSet().ToSlice()
 	sort.Sort(s)
+	return greedyBase(m, s)
+}
+
+// GetMaximumWeightBaseOf() returns a base of input matroid of maximum total Weight().
+// Elements are added greedily in decreasing order of Weight().
+func GetMaximumWeightBaseOf(m Matroid) *Set {
+	var s sorter
+	s = m.GroundSet().ToSlice()
+	sort.Sort(sort.Reverse(s))
+	return greedyBase(m, s)
+}
+
+// greedyBase() adds the elements of s in order, skipping those that break independence.
+func greedyBase(m Matroid, s []Element) *Set {
+	set := EmptySet(m.GroundSet().GetType())
 	for i := 0; i < len(s); i++ {
 		set.Add(s[i])
 		if !m.Independent(set) {
@@ -185,3 +226,45 @@ func (dm *dualMatroid) Rank(s *Set) int {
 func (dm *dualMatroid) Independent(s *Set) bool {
 	return s.Cardinality() == dm.Rank(s)
 }
+
+// Delete() returns the matroid m \ s obtained by deleting s from the GroundSet of m.
+// s must be a subset of the GroundSet.
+func Delete(m Matroid, s *Set) Matroid {
+	return newMinor(m, EmptySet(s.GetType()), s)
+}
+
+// Contract() returns the matroid m / s obtained by contracting s.
+// s must be a subset of the GroundSet.
+func Contract(m Matroid, s *Set) Matroid {
+	return newMinor(m, s, EmptySet(s.GetType()))
+}
+
+func newMinor(m Matroid, contracted, deleted *Set) *minorMatroid {
+	return &minorMatroid{
+		groundSet:  m.GroundSet().Difference(contracted.Union(deleted)),
+		contracted: contracted.Clone(),
+		r:          m.Rank,
+		rc:         m.Rank(contracted),
+	}
+}
+
+type minorMatroid struct {
+	groundSet  *Set
+	contracted *Set
+	// rank function of original matroid
+	r func(*Set) int
+	// rank of contracted in original matroid
+	rc int
+}
+
+func (mm *minorMatroid) GroundSet() *Set {
+	return mm.groundSet
+}
+
+func (mm *minorMatroid) Rank(s *Set) int {
+	return mm.r(s.Union(mm.contracted)) - mm.rc
+}
+
+func (mm *minorMatroid) Independent(s *Set) bool {
+	return s.Cardinality() == mm.Rank(s)
+}
@@ -1 +1,41 @@
 package matroid
+
+import (
+	"testing"
+)
+
+func TestIntersection(t *testing.T) {
+	for i, c := range intersectionTestCases(t) {
+		m1, m2 := c[0], c[1]
+		s, err := Intersection(m1, m2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !m1.Independent(s) || !m2.Independent(s) {
+			t.Errorf("case %d: %v is not a common independent set", i, s.ToSlice())
+		}
+		if expected := maxCommonIndependent(m1, m2); s.Cardinality() != expected {
+			t.Errorf("case %d: size mismatch. expected: %d, actual: %d", i, expected, s.Cardinality())
+		}
+	}
+}
+
+func TestIntersectionWithCertificate(t *testing.T) {
+	for i, c := range intersectionTestCases(t) {
+		m1, m2 := c[0], c[1]
+		s, u, err := IntersectionWithCertificate(m1, m2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !m1.Independent(s) || !m2.Independent(s) {
+			t.Errorf("case %d: %v is not a common independent set", i, s.ToSlice())
+		}
+		if expected := maxCommonIndependent(m1, m2); s.Cardinality() != expected {
+			t.Errorf("case %d: size mismatch. expected: %d, actual: %d", i, expected, s.Cardinality())
+		}
+		rest, _ := m1.GroundSet().Complement(u)
+		if bound := m1.Rank(u) + m2.Rank(rest); bound != s.Cardinality() {
+			t.Errorf("case %d: certificate mismatch. expected: %d, actual: %d", i, s.Cardinality(), bound)
+		}
+	}
+}
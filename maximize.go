@@ -0,0 +1,395 @@
+package matroid
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// MaximizationMethod selects the algorithm of MaximizeSubmodular().
+type MaximizationMethod int
+
+const (
+	// MaximizeGreedy adds the element of largest marginal value as long as one fits: a 1/2-approximation.
+	MaximizeGreedy MaximizationMethod = iota
+	// MaximizeLazyGreedy re-evaluates marginal values only when they may be the largest, which is valid
+	// because marginal values only decrease. It returns the same set as MaximizeGreedy unless ties or
+	// rounding errors order the marginal values differently.
+	MaximizeLazyGreedy
+	// MaximizeLocalSearch improves the greedy solution by swaps while the value increases by a factor
+	// of at least 1+ε/n: a 1/2-approximation at every local optimum.
+	MaximizeLocalSearch
+	// MaximizeContinuousGreedy runs the continuous greedy algorithm on the multilinear extension, estimated
+	// by sampling, and rounds the fractional base by randomized pipage rounding:
+	// a (1-1/e)-approximation in expectation, up to sampling errors.
+	MaximizeContinuousGreedy
+)
+
+const (
+	// localSearchEpsilon is the ε of the improvement threshold of MaximizeLocalSearch.
+	localSearchEpsilon = 0.01
+	// continuousGreedySteps is the number of steps taken from 0 to 1 by MaximizeContinuousGreedy.
+	continuousGreedySteps = 20
+	// continuousGreedySamples is the number of random sets averaged to estimate each gradient.
+	continuousGreedySamples = 40
+)
+
+// MaximizationResult is a solution found by MaximizeSubmodular() and the number of oracle calls spent.
+type MaximizationResult struct {
+	Set   *Set
+	Value float64
+	// ValueCalls counts calls of Value() of the submodular function.
+	ValueCalls int
+	// MatroidCalls counts calls of Rank() and Independent() of the matroid.
+	MatroidCalls int
+}
+
+// MaximizeSubmodular() returns an independent set of m with a large value of f, which must be
+// monotone and submodular on the GroundSet of m, with the given method.
+// MaximizeContinuousGreedy draws random sets from a fixed seed, so its result is reproducible.
+// It returns ErrNotConverged if MinimizeSubmodular() does not converge in pipage rounding.
+func MaximizeSubmodular(f SubmodularFunction, m Matroid, method MaximizationMethod) (*MaximizationResult, error) {
+	if !f.GroundSet().Equal(m.GroundSet()) {
+		return nil, errors.New("the function and the matroid have different ground sets")
+	}
+	cf := &countingFunction{f: f}
+	cm := &countingMatroid{m: m}
+	var s *Set
+	switch method {
+	case MaximizeGreedy:
+		s = greedyMaximize(cf, cm)
+	case MaximizeLazyGreedy:
+		s = lazyGreedyMaximize(cf, cm)
+	case MaximizeLocalSearch:
+		s = localSearchMaximize(cf, cm, greedyMaximize(cf, cm))
+	case MaximizeContinuousGreedy:
+		var err error
+		if s, err = continuousGreedyMaximize(cf, cm, rand.New(rand.NewSource(1))); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown method %d", method)
+	}
+	return &MaximizationResult{
+		Set:          s,
+		Value:        cf.Value(s),
+		ValueCalls:   cf.calls,
+		MatroidCalls: cm.rankCalls + cm.independenceCalls,
+	}, nil
+}
+
+// countingFunction counts the calls of Value().
+type countingFunction struct {
+	f     SubmodularFunction
+	calls int
+}
+
+func (cf *countingFunction) GroundSet() *Set {
+	return cf.f.GroundSet()
+}
+
+func (cf *countingFunction) Value(s *Set) float64 {
+	cf.calls++
+	return cf.f.Value(s)
+}
+
+// countingMatroid counts the calls of Rank() and Independent().
+type countingMatroid struct {
+	m                 Matroid
+	rankCalls         int
+	independenceCalls int
+}
+
+func (cm *countingMatroid) GroundSet() *Set {
+	return cm.m.GroundSet()
+}
+
+func (cm *countingMatroid) Rank(s *Set) int {
+	cm.rankCalls++
+	return cm.m.Rank(s)
+}
+
+func (cm *countingMatroid) Independent(s *Set) bool {
+	cm.independenceCalls++
+	return cm.m.Independent(s)
+}
+
+func greedyMaximize(f SubmodularFunction, m Matroid) *Set {
+	s := EmptySet(m.GroundSet().GetType())
+	candidates := sortedElements(m.GroundSet())
+	for {
+		best, bestValue := -1, math.Inf(-1)
+		var rest []Element
+		for _, e := range candidates {
+			s.Add(e)
+			// an element that does not fit now never fits later
+			if m.Independent(s) {
+				if v := f.Value(s); v > bestValue {
+					best, bestValue = len(rest), v
+				}
+				rest = append(rest, e)
+			}
+			s.Remove(e)
+		}
+		if best < 0 {
+			return s
+		}
+		s.Add(rest[best])
+		candidates = append(rest[:best], rest[best+1:]...)
+	}
+}
+
+// lazyItem is an element with an upper bound on its marginal value, computed when the solution had size round,
+// and the value of the solution with the element at that time.
+type lazyItem struct {
+	e     Element
+	gain  float64
+	value float64
+	round int
+}
+
+// lazyQueue is a max-heap of lazyItems by gain, ties broken by Key().
+type lazyQueue []*lazyItem
+
+func (q lazyQueue) Len() int { return len(q) }
+
+func (q lazyQueue) Less(i, j int) bool {
+	if q[i].gain != q[j].gain {
+		return q[i].gain > q[j].gain
+	}
+	return q[i].e.Key() < q[j].e.Key()
+}
+
+func (q lazyQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *lazyQueue) Push(x interface{}) { *q = append(*q, x.(*lazyItem)) }
+
+func (q *lazyQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+func lazyGreedyMaximize(f SubmodularFunction, m Matroid) *Set {
+	s := EmptySet(m.GroundSet().GetType())
+	value := f.Value(s)
+	q := &lazyQueue{}
+	for _, e := range sortedElements(m.GroundSet()) {
+		*q = append(*q, &lazyItem{e: e, gain: math.Inf(1), round: -1})
+	}
+	heap.Init(q)
+	for q.Len() > 0 {
+		item := heap.Pop(q).(*lazyItem)
+		s.Add(item.e)
+		if !m.Independent(s) {
+			s.Remove(item.e)
+			continue
+		}
+		if item.round == s.Cardinality()-1 {
+			// the evaluated value, as accumulated gains drift by rounding errors
+			value = item.value
+			continue
+		}
+		v := f.Value(s)
+		s.Remove(item.e)
+		item.gain, item.value, item.round = v-value, v, s.Cardinality()
+		heap.Push(q, item)
+	}
+	return s
+}
+
+func localSearchMaximize(f SubmodularFunction, m Matroid, s *Set) *Set {
+	s = s.Clone()
+	value := f.Value(s)
+	n := float64(m.GroundSet().Cardinality())
+	elms := sortedElements(m.GroundSet())
+	for improved := true; improved; {
+		improved = false
+		threshold := value + math.Abs(value)*localSearchEpsilon/n
+		for _, e := range elms {
+			if s.Contains(e) {
+				continue
+			}
+			s.Add(e)
+			if m.Independent(s) {
+				if v := f.Value(s); v > threshold {
+					value, improved = v, true
+					break
+				}
+			}
+			for _, x := range sortedElements(s) {
+				if x.Key() == e.Key() {
+					continue
+				}
+				s.Remove(x)
+				if m.Independent(s) {
+					if v := f.Value(s); v > threshold {
+						value, improved = v, true
+						break
+					}
+				}
+				s.Add(x)
+			}
+			if improved {
+				break
+			}
+			s.Remove(e)
+		}
+	}
+	return s
+}
+
+func continuousGreedyMaximize(f SubmodularFunction, m Matroid, rnd *rand.Rand) (*Set, error) {
+	elms := sortedElements(m.GroundSet())
+	n := len(elms)
+	y := make([]float64, n)
+	delta := 1.0 / continuousGreedySteps
+	for step := 0; step < continuousGreedySteps; step++ {
+		w := multilinearGradient(f, elms, y, rnd)
+		order := make([]int, n)
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			return w[order[i]] > w[order[j]]
+		})
+		sorted := make([]Element, n)
+		for i, j := range order {
+			sorted[i] = elms[j]
+		}
+		base := greedyBase(m, sorted)
+		for i, e := range elms {
+			if base.Contains(e) {
+				y[i] += delta
+			}
+		}
+	}
+	return pipageRound(m, elms, y, rnd)
+}
+
+// multilinearGradient() estimates the partial derivatives of the multilinear extension of f at y,
+// the expected marginal values of each element over random sets containing each element i with probability y[i].
+func multilinearGradient(f SubmodularFunction, elms []Element, y []float64, rnd *rand.Rand) []float64 {
+	w := make([]float64, len(elms))
+	for k := 0; k < continuousGreedySamples; k++ {
+		r := EmptySet(f.GroundSet().GetType())
+		for i, e := range elms {
+			if rnd.Float64() < y[i] {
+				r.Add(e)
+			}
+		}
+		v := f.Value(r)
+		for i, e := range elms {
+			if r.Contains(e) {
+				r.Remove(e)
+				w[i] += v - f.Value(r)
+				r.Add(e)
+			} else {
+				r.Add(e)
+				w[i] += f.Value(r) - v
+				r.Remove(e)
+			}
+		}
+	}
+	for i := range w {
+		w[i] /= continuousGreedySamples
+	}
+	return w
+}
+
+// pipageRound() rounds a point y of the base polytope of m to a base. While two coordinates i and j
+// are fractional and y can move along e_i - e_j in both directions, it moves to one of the two ends
+// of the feasible segment, chosen with probabilities that keep the expectation of y.
+// The ends are found by minimizing r(S) - y(S) over sets separating i and j.
+// After a move the search continues from i, and it stops after a full round without a move or
+// after len(elms)^2 moves, a bound that only rounding errors can reach.
+func pipageRound(m Matroid, elms []Element, y []float64, rnd *rand.Rand) (*Set, error) {
+	t := m.GroundSet().GetType()
+	n := len(elms)
+	fractional := func(v float64) bool {
+		return v > 1e-9 && v < 1-1e-9
+	}
+	// slack() returns the largest step along e_i - e_j keeping y in the base polytope
+	slack := func(i, j int) (float64, error) {
+		rest := EmptySet(t)
+		for k, e := range elms {
+			if k != i && k != j {
+				rest.Add(e)
+			}
+		}
+		_, v, err := MinimizeSubmodular(NewSubmodularFunction(rest, func(s *Set) float64 {
+			s = s.Clone()
+			s.Add(elms[i])
+			var sum float64
+			for k, e := range elms {
+				if s.Contains(e) {
+					sum += y[k]
+				}
+			}
+			return float64(m.Rank(s)) - sum
+		}))
+		return math.Max(0, math.Min(v, math.Min(1-y[i], y[j]))), err
+	}
+	// move() moves y along e_i - e_j for some fractional j and returns false if there is no such move
+	move := func(i int) (bool, error) {
+		for j := range y {
+			if j == i || !fractional(y[j]) {
+				continue
+			}
+			// a pair movable in one direction only cannot move while keeping the expectation;
+			// fractional i and j in a minimal tight set containing a fractional element can move both ways
+			up, err := slack(i, j)
+			if err != nil {
+				return false, err
+			}
+			if up <= 1e-9 {
+				continue
+			}
+			down, err := slack(j, i)
+			if err != nil {
+				return false, err
+			}
+			if down <= 1e-9 {
+				continue
+			}
+			if rnd.Float64() < down/(up+down) {
+				y[i], y[j] = y[i]+up, y[j]-up
+			} else {
+				y[i], y[j] = y[i]-down, y[j]+down
+			}
+			return true, nil
+		}
+		return false, nil
+	}
+	for i, idle, moves := 0, 0, 0; n > 0 && idle < n && moves < n*n; {
+		if fractional(y[i]) {
+			moved, err := move(i)
+			if err != nil {
+				return nil, err
+			}
+			if moved {
+				idle = 0
+				moves++
+				continue
+			}
+		}
+		i, idle = (i+1)%n, idle+1
+	}
+	// y is integral now up to rounding errors; completing greedily by y makes the result a base regardless
+	order := make([]int, len(elms))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return y[order[i]] > y[order[j]]
+	})
+	sorted := make([]Element, len(elms))
+	for i, j := range order {
+		sorted[i] = elms[j]
+	}
+	return greedyBase(m, sorted), nil
+}
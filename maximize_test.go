@@ -0,0 +1,115 @@
+package matroid
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestMaximizeSubmodular(t *testing.T) {
+	gs := newTestSet(type1, 9)
+	elms := sortedElements(gs)
+	// element i covers the items in covers[i]
+	covers := [][]int{{0, 1, 2}, {2, 3}, {3, 4, 5, 6}, {0, 6}, {7, 8, 9}, {1, 9}, {4, 10}, {5, 11, 12}, {12}}
+	index := make(map[string]int)
+	for i, e := range elms {
+		index[e.Key()] = i
+	}
+	coverage := NewSubmodularFunction(gs, func(s *Set) float64 {
+		covered := make(map[int]bool)
+		for _, e := range s.ToSlice() {
+			for _, item := range covers[index[e.Key()]] {
+				covered[item] = true
+			}
+		}
+		return float64(len(covered))
+	})
+	block := func(idx ...int) *Set {
+		s := EmptySet(type1)
+		for _, i := range idx {
+			s.Add(elms[i])
+		}
+		return s
+	}
+	p1, _ := NewPartition(block(0, 1, 2), 1)
+	p2, _ := NewPartition(block(3, 4, 5), 1)
+	p3, _ := NewPartition(block(6, 7, 8), 2)
+	m, err := NewGeneralizedPartitionMatroid([]Partition{p1, p2, p3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var optimum float64
+	for _, s := range subsetsOf(gs) {
+		if m.Independent(s) {
+			optimum = math.Max(optimum, coverage.Value(s))
+		}
+	}
+
+	results := make(map[MaximizationMethod]*MaximizationResult)
+	for _, c := range []struct {
+		name   string
+		method MaximizationMethod
+		ratio  float64
+	}{
+		{"greedy", MaximizeGreedy, 0.5},
+		{"lazy greedy", MaximizeLazyGreedy, 0.5},
+		{"local search", MaximizeLocalSearch, 0.5},
+		{"continuous greedy", MaximizeContinuousGreedy, 1 - 1/math.E},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			r, err := MaximizeSubmodular(coverage, m, c.method)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !m.Independent(r.Set) {
+				t.Errorf("%v is not independent", r.Set.ToSlice())
+			}
+			if r.Value != coverage.Value(r.Set) {
+				t.Errorf("value mismatch. expected: %f, actual: %f", coverage.Value(r.Set), r.Value)
+			}
+			if r.Value < c.ratio*optimum {
+				t.Errorf("value %f is below %f of the optimum %f", r.Value, c.ratio, optimum)
+			}
+			if r.ValueCalls == 0 || r.MatroidCalls == 0 {
+				t.Errorf("oracle calls are not counted")
+			}
+			results[c.method] = r
+		})
+	}
+	greedy, lazy := results[MaximizeGreedy], results[MaximizeLazyGreedy]
+	if greedy != nil && lazy != nil {
+		if !greedy.Set.Equal(lazy.Set) {
+			t.Errorf("lazy greedy found %v instead of %v", lazy.Set.ToSlice(), greedy.Set.ToSlice())
+		}
+		if lazy.ValueCalls > greedy.ValueCalls {
+			t.Errorf("lazy greedy made %d value calls, more than %d of greedy", lazy.ValueCalls, greedy.ValueCalls)
+		}
+	}
+}
+
+func TestPipageRound_OneSidedPair(t *testing.T) {
+	// moving along e_2 - e_3 is possible in one direction only, which must not be chosen over and over
+	var elms []Element
+	for i := 0; i < 6; i++ {
+		elms = append(elms, indexElement(i))
+	}
+	m, err := NewGFMatroid(2, elms, [][]int{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {1, 1, 0}, {1, 0, 1}, {0, 1, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	y := []float64{0.25, 0.5, 0.5, 0.75, 0.25, 0.75}
+	b, err := pipageRound(m, sortedElements(m.GroundSet()), y, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := m.Rank(m.GroundSet()); b.Cardinality() != r || !m.Independent(b) {
+		t.Errorf("%v is not a base", b.ToSlice())
+	}
+	// y is rounded in place, so the move bound must not have stopped it early
+	for i, v := range y {
+		if v > 1e-9 && v < 1-1e-9 {
+			t.Errorf("coordinate %d is still fractional: %f", i, v)
+		}
+	}
+}
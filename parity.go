@@ -0,0 +1,208 @@
+package matroid
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+)
+
+// parityPrimes are the prime fields in which LinearParity() computes, the largest primes below 2^31.
+// Each is tried in turn until the result is verified over the rationals.
+var parityPrimes = []int{2147483647, 2147483629, 2147483587, 2147483579, 2147483563}
+
+// LinearParity() solves the linear matroid parity problem: it returns a largest number of the input
+// pairs of vectors whose union is linearly independent, in the input order.
+// By Lovász's theorem the maximum is half the rank of the skew-symmetric matrix
+// Y = Σ x_i (b_i c_i^T - c_i b_i^T) over the pairs (b_i, c_i) for indeterminates x_i, the size of its
+// largest non-vanishing principal Pfaffian. The rank is computed for random x_i modulo a large prime, and
+// pairs are dropped one by one as long as the rank stays.
+// Entries of the vectors are binary fractions as float64 values and are mapped into the prime field
+// exactly, but a prime may divide a minor that is non-zero over the rationals. So the rank of Y for the
+// same x_i and the independence of the union of the chosen pairs are verified by exact rational
+// elimination, and the next prime is tried if either does not match.
+// The result is independent over the rationals. As in any randomized algebraic algorithm, with a small
+// probability an unlucky choice of x_i makes it smaller than the maximum.
+// Random values are drawn from a fixed seed, so the result is reproducible.
+func LinearParity(pairs [][2]Vector) ([][2]Vector, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	dim := len(pairs[0][0].V)
+	for i, pair := range pairs {
+		for _, v := range pair {
+			if len(v.V) != dim {
+				return nil, fmt.Errorf("pair %d: dimension %d differs from %d", i, len(v.V), dim)
+			}
+			for _, f := range v.V {
+				if math.IsNaN(f) || math.IsInf(f, 0) {
+					return nil, fmt.Errorf("pair %d: entry %g is not finite", i, f)
+				}
+			}
+		}
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, p := range parityPrimes {
+		vs := make([][2][]int, len(pairs))
+		for i, pair := range pairs {
+			for j, v := range pair {
+				vs[i][j] = make([]int, dim)
+				for k, f := range v.V {
+					vs[i][j][k] = floatToGF(f, p)
+				}
+			}
+		}
+		x := make([]int, len(vs))
+		for i := range x {
+			x[i] = 1 + rnd.Intn(p-1)
+		}
+		chosen := make([]bool, len(vs))
+		for i := range chosen {
+			chosen[i] = true
+		}
+		target := rankGF(parityMatrix(vs, x, chosen, dim, p), p)
+		if target != rankRat(parityMatrixRat(pairs, x, dim)) {
+			// p divides a minor of Y
+			continue
+		}
+		for i := range vs {
+			chosen[i] = false
+			if rankGF(parityMatrix(vs, x, chosen, dim, p), p) < target {
+				chosen[i] = true
+			}
+		}
+		var union [][]*big.Rat
+		var result [][2]Vector
+		for i, c := range chosen {
+			if c {
+				union = append(union, ratVector(pairs[i][0]), ratVector(pairs[i][1]))
+				result = append(result, pairs[i])
+			}
+		}
+		if 2*len(result) == target && rankRat(union) == len(union) {
+			return result, nil
+		}
+	}
+	return nil, errors.New("verification failed for every prime")
+}
+
+// parityMatrix() returns the rows of Σ x_i (b_i c_i^T - c_i b_i^T) over the chosen pairs (b_i, c_i) modulo p.
+func parityMatrix(vs [][2][]int, x []int, chosen []bool, dim, p int) [][]int {
+	y := make([][]int, dim)
+	for r := range y {
+		y[r] = make([]int, dim)
+	}
+	for i, pair := range vs {
+		if !chosen[i] {
+			continue
+		}
+		b, c := pair[0], pair[1]
+		for r := 0; r < dim; r++ {
+			if b[r] == 0 && c[r] == 0 {
+				continue
+			}
+			xb, xc := x[i]*b[r]%p, x[i]*c[r]%p
+			for s := 0; s < dim; s++ {
+				y[r][s] = (y[r][s] + xb*c[s]%p + p - xc*b[s]%p) % p
+			}
+		}
+	}
+	return y
+}
+
+// parityMatrixRat() returns the rows of Σ x_i (b_i c_i^T - c_i b_i^T) over all pairs (b_i, c_i) over the rationals.
+func parityMatrixRat(pairs [][2]Vector, x []int, dim int) [][]*big.Rat {
+	y := make([][]*big.Rat, dim)
+	for r := range y {
+		y[r] = make([]*big.Rat, dim)
+		for s := range y[r] {
+			y[r][s] = new(big.Rat)
+		}
+	}
+	t := new(big.Rat)
+	for i, pair := range pairs {
+		b, c := ratVector(pair[0]), ratVector(pair[1])
+		xi := new(big.Rat).SetInt64(int64(x[i]))
+		for r := 0; r < dim; r++ {
+			for s := 0; s < dim; s++ {
+				t.Mul(b[r], c[s])
+				t.Sub(t, new(big.Rat).Mul(c[r], b[s]))
+				y[r][s].Add(y[r][s], t.Mul(t, xi))
+			}
+		}
+	}
+	return y
+}
+
+// ratVector() returns the entries of v as exact rationals.
+func ratVector(v Vector) []*big.Rat {
+	r := make([]*big.Rat, len(v.V))
+	for i, f := range v.V {
+		r[i] = new(big.Rat).SetFloat64(f)
+	}
+	return r
+}
+
+// rankRat() returns the rank over the rationals of the given vectors by Gaussian elimination.
+// The input is not modified.
+func rankRat(vs [][]*big.Rat) int {
+	if len(vs) == 0 {
+		return 0
+	}
+	a := make([][]*big.Rat, len(vs))
+	for i := range vs {
+		a[i] = make([]*big.Rat, len(vs[i]))
+		for j, v := range vs[i] {
+			a[i][j] = new(big.Rat).Set(v)
+		}
+	}
+	rank := 0
+	for c := 0; c < len(a[0]) && rank < len(a); c++ {
+		pivot := -1
+		for r := rank; r < len(a); r++ {
+			if a[r][c].Sign() != 0 {
+				pivot = r
+				break
+			}
+		}
+		if pivot < 0 {
+			continue
+		}
+		a[pivot], a[rank] = a[rank], a[pivot]
+		for r := rank + 1; r < len(a); r++ {
+			if a[r][c].Sign() == 0 {
+				continue
+			}
+			f := new(big.Rat).Quo(a[r][c], a[rank][c])
+			for k := c; k < len(a[r]); k++ {
+				a[r][k].Sub(a[r][k], new(big.Rat).Mul(f, a[rank][k]))
+			}
+		}
+		rank++
+	}
+	return rank
+}
+
+// floatToGF() maps the finite f, a binary fraction m 2^e, to m 2^e modulo the odd prime p.
+func floatToGF(f float64, p int) int {
+	if f == 0 {
+		return 0
+	}
+	frac, exp := math.Frexp(f)
+	// frac * 2^53 is an integer of at most 53 bits
+	m := modGF(int(frac*(1<<53)), p)
+	e := exp - 53
+	b := 2
+	if e < 0 {
+		b, e = inverseGF(2, p), -e
+	}
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			m = m * b % p
+		}
+		b = b * b % p
+	}
+	return m
+}
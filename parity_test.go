@@ -0,0 +1,101 @@
+package matroid
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestLinearParity(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for c := 0; c < 20; c++ {
+		// random small integer vectors, or the arcs of a graph on 6 vertices as e_u - e_v
+		const dim = 6
+		pairs := make([][2]Vector, 6)
+		for i := range pairs {
+			for j := range pairs[i] {
+				v := make([]float64, dim)
+				if c%2 == 0 {
+					for k := range v {
+						v[k] = float64(rnd.Intn(3) - 1)
+					}
+				} else {
+					v[rnd.Intn(dim)] += 1
+					v[rnd.Intn(dim)] -= 1
+				}
+				pairs[i][j] = NewUnweightedVector(v)
+			}
+		}
+
+		// brute force over the subsets of pairs
+		var expected int
+		for mask := 0; mask < 1<<uint(len(pairs)); mask++ {
+			var m Matrix
+			for i, pair := range pairs {
+				if mask&(1<<uint(i)) != 0 {
+					m = append(m, pair[0], pair[1])
+				}
+			}
+			if len(m) > 2*expected && rank(m, 0) == len(m) {
+				expected = len(m) / 2
+			}
+		}
+
+		chosen, err := LinearParity(pairs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(chosen) != expected {
+			t.Errorf("case %d: size mismatch. expected: %d, actual: %d", c, expected, len(chosen))
+		}
+		var m Matrix
+		for _, pair := range chosen {
+			m = append(m, pair[0], pair[1])
+		}
+		if len(m) > 0 && rank(m, 0) != len(m) {
+			t.Errorf("case %d: union of %v is dependent", c, chosen)
+		}
+	}
+}
+
+func TestLinearParity_Fractions(t *testing.T) {
+	// (1/2, 1/4) and (2, 1) are parallel, so the first pair is dependent
+	pairs := [][2]Vector{
+		{NewUnweightedVector([]float64{0.5, 0.25}), NewUnweightedVector([]float64{2, 1})},
+		{NewUnweightedVector([]float64{1, 0}), NewUnweightedVector([]float64{0, 1})},
+	}
+	chosen, err := LinearParity(pairs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chosen) != 1 || chosen[0][0].Key() != pairs[1][0].Key() {
+		t.Errorf("chosen pairs mismatch. expected: %v, actual: %v", pairs[1:], chosen)
+	}
+}
+
+func TestLinearParity_Errors(t *testing.T) {
+	pairs := [][2]Vector{
+		{NewUnweightedVector([]float64{1, 0}), NewUnweightedVector([]float64{0, 1, 0})},
+	}
+	if _, err := LinearParity(pairs); err == nil {
+		t.Errorf("vectors of different dimensions are accepted")
+	}
+}
+
+func TestLinearParity_PrimeEntry(t *testing.T) {
+	// (0, 2^31 - 1) vanishes modulo the first prime but not over the rationals
+	pairs := [][2]Vector{
+		{NewUnweightedVector([]float64{1, 0}), NewUnweightedVector([]float64{0, 2147483647})},
+	}
+	chosen, err := LinearParity(pairs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chosen) != 1 {
+		t.Errorf("size mismatch. expected: %d, actual: %d", 1, len(chosen))
+	}
+	for _, p := range parityPrimes {
+		if !isPrime(p) {
+			t.Errorf("%d is not prime", p)
+		}
+	}
+}
@@ -1,11 +1,27 @@
 package matroid
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
+
+// Uncovered tells how a PartitionMatroid treats elements of its GroundSet that are in no block.
+type Uncovered int
+
+const (
+	// UncoveredLoops makes uncovered elements loops, which are in no independent set.
+	UncoveredLoops Uncovered = iota
+	// UncoveredFree makes uncovered elements coloops, which are in every base.
+	UncoveredFree
+)
 
 // `partition` field has other two fields info, but we preserve them for convenient
 type PartitionMatroid struct {
 	groundSet  *Set
 	partitions []Partition
+	// index[e.Key()] is the index in partitions of the block containing e
+	index     map[string]int
+	uncovered Uncovered
 }
 
 // partition of ground set with intersect threshold n
@@ -15,12 +31,37 @@ type Partition struct {
 	n   int
 }
 
+// NewPartition() returns the block set with the given capacity.
+// capacity must be non-negative.
+func NewPartition(set *Set, capacity int) (Partition, error) {
+	if capacity < 0 {
+		return Partition{}, fmt.Errorf("negative capacity %d", capacity)
+	}
+	return Partition{
+		set: set,
+		n:   capacity,
+	}, nil
+}
+
+// Set() returns the elements of the block.
+func (p Partition) Set() *Set {
+	return p.set
+}
+
+// Capacity() returns the maximum number of elements of the block in an independent set.
+func (p Partition) Capacity() int {
+	return p.n
+}
+
 func UnionAllPartitions(p []Partition) (*Set, error) {
+	if len(p) == 0 {
+		return nil, errors.New("no partitions given")
+	}
 	s := EmptySet(p[0].set.GetType())
 	var c int
 	var r int
 	for _, pp := range p {
-		s.Union(pp.set)
+		s = s.Union(pp.set)
 		c += pp.set.Cardinality()
 		r += pp.n
 	}
@@ -35,9 +76,17 @@ func (p PartitionMatroid) GroundSet() *Set {
 }
 
 func (p PartitionMatroid) Rank(s *Set) int {
+	counts := make([]int, len(p.partitions))
 	var r int
-	for _, pp := range p.partitions {
-		r += min(s.Intersect(pp.set).Cardinality(), pp.n)
+	for e := range s.Iter() {
+		if i, ok := p.index[e.Key()]; ok {
+			counts[i]++
+		} else if p.uncovered == UncoveredFree {
+			r++
+		}
+	}
+	for i, pp := range p.partitions {
+		r += min(counts[i], pp.n)
 	}
 	return r
 }
@@ -46,6 +95,26 @@ func (p PartitionMatroid) Independent(s *Set) bool {
 	return s.Cardinality() == p.Rank(s)
 }
 
+// BlockOf() returns the block containing e, and false if e is in no block.
+func (p PartitionMatroid) BlockOf(e Element) (Partition, bool) {
+	i, ok := p.index[e.Key()]
+	if !ok {
+		return Partition{}, false
+	}
+	return p.partitions[i], true
+}
+
+// Partitions() returns the blocks of the matroid.
+// The returned slice must not be modified.
+func (p PartitionMatroid) Partitions() []Partition {
+	return p.partitions
+}
+
+// Uncovered() returns how elements in no block are treated.
+func (p PartitionMatroid) Uncovered() Uncovered {
+	return p.uncovered
+}
+
 func NewPartitionMatroid(s ...*Set) (*PartitionMatroid, error) {
 	var p []Partition
 	for _, ss := range s {
@@ -59,8 +128,34 @@ func NewPartitionMatroid(s ...*Set) (*PartitionMatroid, error) {
 
 func NewGeneralizedPartitionMatroid(p []Partition) (*PartitionMatroid, error) {
 	s, err := UnionAllPartitions(p)
-	return &PartitionMatroid{
-		groundSet:  s,
+	if err != nil {
+		return nil, err
+	}
+	return NewPartitionMatroidOn(s, p, UncoveredLoops)
+}
+
+// NewPartitionMatroidOn() returns the partition matroid on gs with the given disjoint blocks.
+// Every block must be a subset of gs; elements of gs in no block are treated according to uncovered.
+func NewPartitionMatroidOn(gs *Set, p []Partition, uncovered Uncovered) (*PartitionMatroid, error) {
+	pm := &PartitionMatroid{
+		groundSet:  gs,
 		partitions: p,
-	}, err
+		index:      make(map[string]int),
+		uncovered:  uncovered,
+	}
+	for i, pp := range p {
+		if pp.n < 0 {
+			return nil, fmt.Errorf("negative capacity %d", pp.n)
+		}
+		if !pp.set.IsSubsetOf(gs) {
+			return nil, errors.New("blocks must be subsets of the ground set")
+		}
+		for _, e := range pp.set.ToSlice() {
+			if _, ok := pm.index[e.Key()]; ok {
+				return nil, errors.New("given sets are not disjoint")
+			}
+			pm.index[e.Key()] = i
+		}
+	}
+	return pm, nil
 }
@@ -0,0 +1,74 @@
+package matroid
+
+import (
+	"testing"
+)
+
+func TestPartitionMatroid(t *testing.T) {
+	gs := newTestSet(type1, 6)
+	elms := sortedElements(gs)
+	block := func(idx ...int) *Set {
+		s := EmptySet(type1)
+		for _, i := range idx {
+			s.Add(elms[i])
+		}
+		return s
+	}
+	p1, err := NewPartition(block(0, 1, 2), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p2, err := NewPartition(block(3), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loops, err := NewPartitionMatroidOn(gs, []Partition{p1, p2}, UncoveredLoops)
+	if err != nil {
+		t.Fatal(err)
+	}
+	free, err := NewPartitionMatroidOn(gs, []Partition{p1, p2}, UncoveredFree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name     string
+		m        *PartitionMatroid
+		s        *Set
+		expected int
+	}{
+		{"block over capacity", loops, block(0, 1, 2), 2},
+		{"zero capacity", loops, block(2, 3), 1},
+		{"uncovered loops", loops, block(0, 4, 5), 1},
+		{"uncovered free", free, block(0, 4, 5), 3},
+		{"ground set", free, gs, 4},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := c.m.Rank(c.s); actual != c.expected {
+				t.Errorf("rank mismatch. expected: %d, actual: %d", c.expected, actual)
+			}
+		})
+	}
+
+	if b, ok := loops.BlockOf(elms[1]); !ok || b.Capacity() != 2 || !b.Set().Equal(p1.Set()) {
+		t.Errorf("BlockOf mismatch for %s", elms[1].Key())
+	}
+	if _, ok := loops.BlockOf(elms[4]); ok {
+		t.Errorf("%s should be uncovered", elms[4].Key())
+	}
+
+	pm, err := NewGeneralizedPartitionMatroid([]Partition{p1, p2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected, actual := 4, pm.GroundSet().Cardinality(); expected != actual {
+		t.Errorf("ground set size mismatch. expected: %d, actual: %d", expected, actual)
+	}
+	if _, err := NewPartition(block(0), -1); err == nil {
+		t.Error("expected an error for a negative capacity")
+	}
+	if _, err := NewGeneralizedPartitionMatroid([]Partition{p1, p1}); err == nil {
+		t.Error("expected an error for overlapping blocks")
+	}
+}
@@ -0,0 +1,155 @@
+package matroid
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PavingMatroid is a matroid of rank r whose circuits all have at least r elements.
+// It is determined by its dependent hyperplanes, the hyperplanes with at least r elements:
+// a set is independent if it has fewer than r elements, or exactly r elements and lies in no dependent hyperplane.
+type PavingMatroid struct {
+	groundSet   *Set
+	r           int
+	hyperplanes []*Set
+}
+
+func (p *PavingMatroid) GroundSet() *Set {
+	return p.groundSet
+}
+
+func (p *PavingMatroid) Rank(s *Set) int {
+	if s.Cardinality() < p.r {
+		return s.Cardinality()
+	}
+	for _, h := range p.hyperplanes {
+		if s.IsSubsetOf(h) {
+			return p.r - 1
+		}
+	}
+	return p.r
+}
+
+func (p *PavingMatroid) Independent(s *Set) bool {
+	return s.Cardinality() == p.Rank(s)
+}
+
+// Hyperplanes() returns the dependent hyperplanes of the matroid.
+// The returned slice must not be modified.
+func (p *PavingMatroid) Hyperplanes() []*Set {
+	return p.hyperplanes
+}
+
+// NewPavingMatroid() returns the paving matroid of rank r on gs with the given dependent hyperplanes.
+// Each hyperplane must be a subset of gs with at least r elements, and any two of them
+// must share at most r-2 elements, so that every (r-1)-subset lies in at most one hyperplane.
+func NewPavingMatroid(gs *Set, r int, hyperplanes []*Set) (*PavingMatroid, error) {
+	if r < 1 || r > gs.Cardinality() {
+		return nil, fmt.Errorf("rank %d is out of range", r)
+	}
+	for i, h := range hyperplanes {
+		if !h.IsSubsetOf(gs) {
+			return nil, errors.New("hyperplanes must be subsets of the ground set")
+		}
+		if h.Cardinality() < r {
+			return nil, fmt.Errorf("hyperplane of %d elements is independent in rank %d", h.Cardinality(), r)
+		}
+		for _, h2 := range hyperplanes[:i] {
+			if h.Intersect(h2).Cardinality() > r-2 {
+				return nil, errors.New("two hyperplanes share an independent set of rank r-1")
+			}
+		}
+	}
+	p := &PavingMatroid{
+		groundSet: gs,
+		r:         r,
+	}
+	for _, h := range hyperplanes {
+		p.hyperplanes = append(p.hyperplanes, h.Clone())
+	}
+	return p, nil
+}
+
+// SparsePavingMatroid is a paving matroid whose dual is paving as well.
+// Its dependent hyperplanes have exactly r elements, so they are both circuits and hyperplanes.
+type SparsePavingMatroid struct {
+	PavingMatroid
+}
+
+// NewSparsePavingMatroid() returns the sparse paving matroid of rank r on gs with the given circuit-hyperplanes.
+// Each circuit-hyperplane must have exactly r elements, and any two of them must share at most r-2 elements.
+func NewSparsePavingMatroid(gs *Set, r int, circuitHyperplanes []*Set) (*SparsePavingMatroid, error) {
+	for _, h := range circuitHyperplanes {
+		if h.Cardinality() != r {
+			return nil, fmt.Errorf("circuit-hyperplane of %d elements in rank %d", h.Cardinality(), r)
+		}
+	}
+	p, err := NewPavingMatroid(gs, r, circuitHyperplanes)
+	if err != nil {
+		return nil, err
+	}
+	return &SparsePavingMatroid{PavingMatroid: *p}, nil
+}
+
+// RelaxCircuitHyperplane() returns the matroid obtained from m by relaxing x,
+// which must be both a circuit and a hyperplane of m: x becomes a base and every other rank stays.
+// Relaxing a circuit-hyperplane of a representable matroid often gives a non-representable one,
+// as the non-Pappus matroid is obtained from the Pappus matroid.
+// Paving and sparse paving matroids stay so, and are returned as such.
+func RelaxCircuitHyperplane(m Matroid, x *Set) (Matroid, error) {
+	gs := m.GroundSet()
+	r := m.Rank(gs)
+	if !x.IsSubsetOf(gs) {
+		return nil, errors.New("x must be a subset of the ground set")
+	}
+	x = x.Clone()
+	if x.Cardinality() != r || m.Rank(x) != r-1 {
+		return nil, errors.New("x is not a circuit-hyperplane")
+	}
+	for _, e := range x.ToSlice() {
+		x.Remove(e)
+		independent := m.Independent(x)
+		x.Add(e)
+		if !independent {
+			return nil, errors.New("x is not a circuit")
+		}
+	}
+	for _, e := range gs.Difference(x).ToSlice() {
+		x.Add(e)
+		spanning := m.Rank(x) == r
+		x.Remove(e)
+		if !spanning {
+			return nil, errors.New("x is not a hyperplane")
+		}
+	}
+
+	switch p := m.(type) {
+	case *SparsePavingMatroid:
+		return &SparsePavingMatroid{PavingMatroid: *p.relax(x)}, nil
+	case *PavingMatroid:
+		return p.relax(x), nil
+	}
+	return &rankMatroid{
+		groundSet: gs,
+		rank: func(s *Set) int {
+			if s.Equal(x) {
+				return r
+			}
+			return m.Rank(s)
+		},
+	}, nil
+}
+
+// relax() returns a copy of p without the dependent hyperplane x.
+func (p *PavingMatroid) relax(x *Set) *PavingMatroid {
+	relaxed := &PavingMatroid{
+		groundSet: p.groundSet,
+		r:         p.r,
+	}
+	for _, h := range p.hyperplanes {
+		if !h.Equal(x) {
+			relaxed.hyperplanes = append(relaxed.hyperplanes, h)
+		}
+	}
+	return relaxed
+}
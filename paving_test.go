@@ -0,0 +1,75 @@
+package matroid
+
+import (
+	"testing"
+)
+
+func TestPavingMatroid(t *testing.T) {
+	gs := newTestSet(type1, 7)
+	elms := sortedElements(gs)
+	set := func(idx ...int) *Set {
+		s := EmptySet(type1)
+		for _, i := range idx {
+			s.Add(elms[i])
+		}
+		return s
+	}
+	// the Fano plane with points 0, ..., 6 represented by the non-zero vectors of GF(2)^3
+	var columns [][]int
+	for v := 1; v < 8; v++ {
+		columns = append(columns, []int{v & 1, v >> 1 & 1, v >> 2 & 1})
+	}
+	fano, err := NewGFMatroid(2, elms, columns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// point i is the vector i+1, and three points are collinear if their vectors sum to zero
+	lines := []*Set{set(0, 1, 2), set(0, 3, 4), set(0, 5, 6), set(1, 3, 5), set(1, 4, 6), set(2, 3, 6), set(2, 4, 5)}
+	sp, err := NewSparsePavingMatroid(gs, 3, lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range subsetsOf(gs) {
+		if expected, actual := fano.Rank(s), sp.Rank(s); expected != actual {
+			t.Errorf("rank mismatch for %v. expected: %d, actual: %d", s.ToSlice(), expected, actual)
+		}
+	}
+
+	// relaxing a line of the Fano plane gives the non-Fano matroid, which is ternary but not binary
+	relaxed, err := RelaxCircuitHyperplane(sp, lines[6])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := relaxed.(*SparsePavingMatroid); !ok {
+		t.Errorf("relaxation of a sparse paving matroid should be sparse paving")
+	}
+	if g, _ := IsBinary(relaxed); g != nil {
+		t.Errorf("non-Fano should not be binary")
+	}
+	if g, _ := IsTernary(relaxed); g == nil {
+		t.Errorf("non-Fano should be ternary")
+	}
+	generic, err := RelaxCircuitHyperplane(fano, lines[6])
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameRanks(t, relaxed, generic)
+
+	if _, err := RelaxCircuitHyperplane(fano, set(0, 1, 3)); err == nil {
+		t.Error("expected an error for a base")
+	}
+	if _, err := NewSparsePavingMatroid(gs, 3, []*Set{set(0, 1, 2), set(0, 1, 3)}); err == nil {
+		t.Error("expected an error for hyperplanes sharing two points")
+	}
+	if _, err := NewPavingMatroid(gs, 3, []*Set{set(0, 1)}); err == nil {
+		t.Error("expected an error for a small hyperplane")
+	}
+	// a paving matroid of rank 3 with a four-point line
+	p, err := NewPavingMatroid(gs, 3, []*Set{set(0, 1, 2, 3)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected, actual := 2, p.Rank(set(0, 1, 2, 3)); expected != actual {
+		t.Errorf("rank mismatch. expected: %d, actual: %d", expected, actual)
+	}
+}
@@ -0,0 +1,182 @@
+package matroid
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// InIndependencePolytope() returns true if x is in the independence polytope of m,
+// the convex hull of the incidence vectors of independent sets:
+// x >= 0 and x(S) <= r(S) for every subset S of the GroundSet.
+// Vectors are maps from Key() of elements to coordinates, and missing keys are zero.
+// Constraints violated by at most 1e-9 are considered satisfied.
+// It returns ErrNotConverged if MinimizeSubmodular() does not converge.
+func InIndependencePolytope(m Matroid, x map[string]float64) (bool, error) {
+	return PolymatroidOf(m).Contains(x)
+}
+
+// InBasePolytope() returns true if x is in the base polytope of m, the convex hull of the incidence
+// vectors of bases: the face of the independence polytope where x(E) = r(E), with the same tolerance.
+// It returns ErrNotConverged if MinimizeSubmodular() does not converge.
+func InBasePolytope(m Matroid, x map[string]float64) (bool, error) {
+	return PolymatroidOf(m).InBasePolyhedron(x)
+}
+
+// SeparateIndependencePolytope() returns a set S whose rank inequality x(S) <= r(S) is violated most,
+// and false if x satisfies every rank inequality up to 1e-9. Non-negativity of x is not checked.
+// It returns ErrNotConverged if MinimizeSubmodular() does not converge.
+func SeparateIndependencePolytope(m Matroid, x map[string]float64) (*Set, bool, error) {
+	s, v, err := PolymatroidOf(m).Separate(x)
+	if err != nil {
+		return nil, false, err
+	}
+	if v > -submodularTolerance {
+		return nil, false, nil
+	}
+	return s, true, nil
+}
+
+// DecomposeIntoBases() expresses a point x of the base polytope of m as a convex combination of bases,
+// returning the bases and their positive coefficients summing to one.
+// Each step subtracts the largest multiple of a base B keeping the rest in the base polytope, which
+// creates a new tight set or zero coordinate. B is chosen greedily along a chain of tight sets so that
+// it is tight on them as well; the largest multiple is the minimum over S of
+// (r(S) - x(S)) / (r(S) - |B ∩ S|), found by Dinkelbach's method with MinimizeSubmodular().
+// It returns an error if x is not in the base polytope up to 1e-9, and ErrNotConverged if
+// MinimizeSubmodular() does not converge.
+func DecomposeIntoBases(m Matroid, x map[string]float64) ([]*Set, []float64, error) {
+	ok, err := InBasePolytope(m, x)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		return nil, nil, errors.New("x is not in the base polytope")
+	}
+	gs := m.GroundSet()
+	elms := sortedElements(gs)
+	y := make(map[string]float64, len(elms))
+	for _, e := range elms {
+		y[e.Key()] = x[e.Key()]
+	}
+	chain := []*Set{EmptySet(gs.GetType()), gs}
+
+	var bases []*Set
+	var coefficients []float64
+	remaining := 1.0
+	for remaining > submodularTolerance {
+		support := EmptySet(gs.GetType())
+		for _, e := range elms {
+			if y[e.Key()] > submodularTolerance {
+				support.Add(e)
+			}
+		}
+		// the support is tight, so bases avoiding zero coordinates exist
+		chain = refineChain(chain, support)
+		b := chainBase(m, chain, elms, y)
+		lambda, s, err := maximumBaseStep(m, y, b)
+		if err != nil {
+			return nil, nil, err
+		}
+		if lambda <= submodularTolerance {
+			if s == nil {
+				return nil, nil, errors.New("decomposition stalled due to rounding errors")
+			}
+			n := len(chain)
+			if chain = refineChain(chain, s); len(chain) == n {
+				return nil, nil, errors.New("decomposition stalled due to rounding errors")
+			}
+			continue
+		}
+		lambda = math.Min(lambda, 1)
+		bases = append(bases, b)
+		coefficients = append(coefficients, remaining*lambda)
+		if lambda >= 1-submodularTolerance {
+			break
+		}
+		for _, e := range elms {
+			v := y[e.Key()]
+			if b.Contains(e) {
+				v -= lambda
+			}
+			y[e.Key()] = math.Max(0, v/(1-lambda))
+		}
+		remaining *= 1 - lambda
+		if s != nil {
+			chain = refineChain(chain, s)
+		}
+	}
+	// absorb rounding errors so that the coefficients sum to one
+	var sum float64
+	for _, c := range coefficients {
+		sum += c
+	}
+	for i := range coefficients {
+		coefficients[i] /= sum
+	}
+	return bases, coefficients, nil
+}
+
+// maximumBaseStep() returns the largest λ <= 1 such that (y - λB) / (1 - λ) stays in the base polytope,
+// and the set S attaining the bound, or nil if the bound comes from a coordinate or from λ = 1.
+func maximumBaseStep(m Matroid, y map[string]float64, b *Set) (float64, *Set, error) {
+	lambda := 1.0
+	for _, e := range b.ToSlice() {
+		lambda = math.Min(lambda, y[e.Key()])
+	}
+	var limit *Set
+	for {
+		l := lambda
+		g := NewSubmodularFunction(m.GroundSet(), func(s *Set) float64 {
+			return (1-l)*float64(m.Rank(s)) - sumOver(y, s) + l*float64(s.Intersect(b).Cardinality())
+		})
+		s, v, err := MinimizeSubmodular(g)
+		if err != nil {
+			return 0, nil, err
+		}
+		if v > -submodularTolerance {
+			return lambda, limit, nil
+		}
+		r := float64(m.Rank(s))
+		next := (r - sumOver(y, s)) / (r - float64(s.Intersect(b).Cardinality()))
+		// each iteration strictly decreases λ unless rounding errors interfere
+		if next >= lambda || next <= 0 {
+			return math.Max(0, math.Min(next, lambda)), s, nil
+		}
+		lambda, limit = next, s
+	}
+}
+
+// refineChain() inserts into a chain of tight sets from the empty set to the GroundSet the sets
+// T_i ∪ (s ∩ T_{i+1}) for consecutive T_i and T_{i+1}, which are tight if s is.
+func refineChain(chain []*Set, s *Set) []*Set {
+	refined := []*Set{chain[0]}
+	for i := 0; i+1 < len(chain); i++ {
+		u := chain[i].Union(s.Intersect(chain[i+1]))
+		if u.Cardinality() > chain[i].Cardinality() && u.Cardinality() < chain[i+1].Cardinality() {
+			refined = append(refined, u)
+		}
+		refined = append(refined, chain[i+1])
+	}
+	return refined
+}
+
+// chainBase() returns a greedy base of m taking the elements of each set of the chain before the others,
+// so that it meets each set T of the chain in r(T) elements. Within a level, larger coordinates of y come first.
+func chainBase(m Matroid, chain []*Set, elms []Element, y map[string]float64) *Set {
+	level := make(map[string]int, len(elms))
+	for i := len(chain) - 1; i >= 0; i-- {
+		for _, e := range chain[i].ToSlice() {
+			level[e.Key()] = i
+		}
+	}
+	order := append([]Element(nil), elms...)
+	sort.SliceStable(order, func(i, j int) bool {
+		ki, kj := order[i].Key(), order[j].Key()
+		if level[ki] != level[kj] {
+			return level[ki] < level[kj]
+		}
+		return y[ki] > y[kj]
+	})
+	return greedyBase(m, order)
+}
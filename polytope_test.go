@@ -0,0 +1,80 @@
+package matroid
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDecomposeIntoBases(t *testing.T) {
+	// K4 as a graphic matroid
+	d := newTestDigraph(0, [2]int64{1, 2}, [2]int64{1, 3}, [2]int64{1, 4}, [2]int64{2, 3}, [2]int64{2, 4}, [2]int64{3, 4})
+	g := NewGraphicMatroid(d)
+	elms := sortedElements(g.GroundSet())
+	vector := func(values ...float64) map[string]float64 {
+		x := make(map[string]float64)
+		for i, v := range values {
+			x[elms[i].Key()] = v
+		}
+		return x
+	}
+
+	cases := []struct {
+		name string
+		x    map[string]float64
+		base bool
+	}{
+		{"uniform", vector(0.5, 0.5, 0.5, 0.5, 0.5, 0.5), true},
+		{"star", vector(1, 1, 1, 0, 0, 0), true},
+		{"mixed", vector(0.9, 0.6, 0.3, 0.4, 0.5, 0.3), true},
+		{"triangle", vector(1, 1, 0, 1, 0, 0), false},
+		{"below", vector(0.5, 0.5, 0.5, 0.5, 0.5, 0), false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual, err := InBasePolytope(g, c.x); err != nil || actual != c.base {
+				t.Errorf("membership mismatch. expected: %t, actual: %t (%v)", c.base, actual, err)
+			}
+			bases, coefficients, err := DecomposeIntoBases(g, c.x)
+			if !c.base {
+				if err == nil {
+					t.Error("expected an error outside the base polytope")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(bases) > len(elms)+1 {
+				t.Errorf("%d bases are more than Carathéodory's bound", len(bases))
+			}
+			sum := make(map[string]float64)
+			for i, b := range bases {
+				if b.Cardinality() != 3 || !g.Independent(b) {
+					t.Errorf("%v is not a base", b.ToSlice())
+				}
+				if coefficients[i] <= 0 {
+					t.Errorf("coefficient %f is not positive", coefficients[i])
+				}
+				for _, e := range b.ToSlice() {
+					sum[e.Key()] += coefficients[i]
+				}
+			}
+			for _, e := range elms {
+				if math.Abs(sum[e.Key()]-c.x[e.Key()]) > 1e-6 {
+					t.Errorf("coordinate mismatch for %s. expected: %f, actual: %f", e.Key(), c.x[e.Key()], sum[e.Key()])
+				}
+			}
+		})
+	}
+
+	s, ok, err := SeparateIndependencePolytope(g, vector(1, 1, 0, 1, 0, 0))
+	if err != nil || !ok || s.Cardinality() != 3 {
+		t.Errorf("the triangle should be separated")
+	}
+	if _, ok, err := SeparateIndependencePolytope(g, vector(0.5, 0.5, 0.5, 0.5, 0.5, 0)); err != nil || ok {
+		t.Errorf("no rank inequality should be violated")
+	}
+	if ok, err := InIndependencePolytope(g, vector(0.5, 0.5, 0.5, 0.5, 0.5, 0)); err != nil || !ok {
+		t.Errorf("point should be in the independence polytope")
+	}
+}
@@ -0,0 +1,92 @@
+package matroid_test
+
+import (
+	"sort"
+	"testing"
+
+	matroid "github.com/yuichiro12/go-matroid"
+	"github.com/yuichiro12/go-matroid/catalog"
+)
+
+func fixtures() map[string]matroid.Matroid {
+	return map[string]matroid.Matroid{
+		"U(2,5)": catalog.Uniform(2, 5),
+		"F7":     catalog.Fano(),
+		"F7-":    catalog.NonFano(),
+		"V8":     catalog.Vamos(),
+		"W3":     catalog.Wheel(3),
+		"W^3":    catalog.Whirl(3),
+		"Z4":     catalog.BinarySpike(4),
+	}
+}
+
+// subsets() returns every subset of the GroundSet of m.
+func subsets(m matroid.Matroid) []*matroid.Set {
+	elms := m.GroundSet().ToSlice()
+	sort.Slice(elms, func(i, j int) bool { return elms[i].Key() < elms[j].Key() })
+	var ss []*matroid.Set
+	for mask := 0; mask < 1<<uint(len(elms)); mask++ {
+		s := matroid.EmptySet(m.GroundSet().GetType())
+		for i, e := range elms {
+			if mask&(1<<uint(i)) != 0 {
+				s.Add(e)
+			}
+		}
+		ss = append(ss, s)
+	}
+	return ss
+}
+
+func TestRankAxioms(t *testing.T) {
+	for name, m := range fixtures() {
+		t.Run(name, func(t *testing.T) {
+			for _, s := range subsets(m) {
+				r := m.Rank(s)
+				if r < 0 || r > s.Cardinality() {
+					t.Fatalf("rank %d of %v out of range", r, s)
+				}
+				for _, e := range m.GroundSet().ToSlice() {
+					if s.Contains(e) {
+						continue
+					}
+					s0 := s.Clone()
+					s0.Add(e)
+					if r0 := m.Rank(s0); r0 < r || r0 > r+1 {
+						t.Fatalf("rank of %v is %d but rank of %v is %d", s, r, s0, r0)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDualOfMinor(t *testing.T) {
+	for name, m := range fixtures() {
+		t.Run(name, func(t *testing.T) {
+			x := matroid.NewSet(m.GroundSet().GetType(), m.GroundSet().ToSlice()[:2]...)
+			// (M / X)* = M* \ X
+			m1 := matroid.Dual(matroid.Contract(m, x))
+			m2 := matroid.Delete(matroid.Dual(m), x)
+			for _, s := range subsets(m1) {
+				if m1.Rank(s) != m2.Rank(s) {
+					t.Fatalf("rank of %v mismatch. (M/X)*: %d, M*\\X: %d", s, m1.Rank(s), m2.Rank(s))
+				}
+			}
+		})
+	}
+}
+
+func TestCanonicalFormOfDoubleDual(t *testing.T) {
+	for name, m := range fixtures() {
+		t.Run(name, func(t *testing.T) {
+			c1, err := matroid.CanonicalForm(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			c2, _ := matroid.CanonicalForm(matroid.Dual(matroid.Dual(m)))
+			if c1 != c2 {
+				t.Errorf("canonical forms of M and M** differ")
+			}
+		})
+	}
+}
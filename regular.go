@@ -0,0 +1,534 @@
+package matroid
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// DecompositionKind is the kind of a node of a Decomposition.
+type DecompositionKind string
+
+const (
+	GraphicPiece   DecompositionKind = "graphic"
+	CographicPiece DecompositionKind = "cographic"
+	R10Piece       DecompositionKind = "R10"
+	OneSumNode     DecompositionKind = "1-sum"
+	TwoSumNode     DecompositionKind = "2-sum"
+	ThreeSumNode   DecompositionKind = "3-sum"
+)
+
+// maxRegularSize is the largest ground set IsRegular() accepts, as separations and vertex stars
+// are enumerated as bit masks of the GroundSet.
+const maxRegularSize = 64
+
+// Decomposition is a node of the tree decomposing a regular matroid into graphic, cographic
+// and R10 pieces glued by 1-, 2- and 3-sums, as given by Seymour's decomposition theorem.
+// Leaves are pieces and inner nodes are sums of their children.
+type Decomposition struct {
+	Kind DecompositionKind
+	// Matroid is the binary representation of this node.
+	Matroid *GFMatroid
+	// Markers are the elements shared by the children of a 2- or 3-sum and deleted from the result.
+	// They are nil for 1-sums and pieces.
+	Markers  *Set
+	Children []*Decomposition
+}
+
+// Marker is an element introduced by a decomposition to glue two pieces.
+// It has the ElementType of the decomposed matroid.
+type Marker struct {
+	Type ElementType
+	Id   int
+}
+
+func (mk Marker) GetType() ElementType {
+	return mk.Type
+}
+
+func (mk Marker) Key() string {
+	return fmt.Sprintf("marker:%d", mk.Id)
+}
+
+func (mk Marker) Value() interface{} {
+	return mk.Id
+}
+
+func (mk Marker) Weight() float64 {
+	return 0
+}
+
+// indexElement is an element identified by an index, used for matroids built in this package.
+type indexElement int
+
+const indexType ElementType = "INDEX"
+
+func (i indexElement) GetType() ElementType {
+	return indexType
+}
+
+func (i indexElement) Key() string {
+	return fmt.Sprintf("%d", int(i))
+}
+
+func (i indexElement) Value() interface{} {
+	return int(i)
+}
+
+func (i indexElement) Weight() float64 {
+	return 0
+}
+
+// IsRegular() returns the decomposition of m into graphic, cographic and R10 pieces if m is regular.
+// Otherwise it returns a minor of m that is an excluded minor for binary or ternary matroids;
+// a matroid is regular if and only if it is both binary and ternary.
+// Like IsBinary(), this enumerates subsets of the GroundSet and is meant for small matroids;
+// it returns an error if the GroundSet has more than 64 elements.
+func IsRegular(m Matroid) (*Decomposition, *Minor, error) {
+	if n := m.GroundSet().Cardinality(); n > maxRegularSize {
+		return nil, nil, fmt.Errorf("ground set has %d elements; at most %d are supported", n, maxRegularSize)
+	}
+	g, minor := IsBinary(m)
+	if minor != nil {
+		return nil, minor, nil
+	}
+	if _, minor := IsTernary(m); minor != nil {
+		return nil, minor, nil
+	}
+	var markers int
+	d, err := decompose(g, &markers)
+	if err != nil {
+		return nil, nil, err
+	}
+	return d, nil, nil
+}
+
+func decompose(g *GFMatroid, markers *int) (*Decomposition, error) {
+	d := &Decomposition{Matroid: g}
+	elms := sortedElements(g.GroundSet())
+
+	if comps := connectedComponents(g); len(comps) > 1 {
+		d.Kind = OneSumNode
+		for _, c := range comps {
+			child, err := decompose(restrictGF(g, c, nil), markers)
+			if err != nil {
+				return nil, err
+			}
+			d.Children = append(d.Children, child)
+		}
+		return d, nil
+	}
+	if x := findSeparation(g, elms, 2, 2); x != 0 {
+		d.Kind = TwoSumNode
+		if err := d.glue(x, elms, markers); err != nil {
+			return nil, err
+		}
+		return d, nil
+	}
+	if len(elms) <= 3 || isGraphicGF(g) {
+		d.Kind = GraphicPiece
+		return d, nil
+	}
+	if isGraphicGF(dualGF(g)) {
+		d.Kind = CographicPiece
+		return d, nil
+	}
+	if len(elms) == 10 && g.Rank(g.GroundSet()) == 5 {
+		if _, ok, _ := Isomorphic(g, r10()); ok {
+			d.Kind = R10Piece
+			return d, nil
+		}
+	}
+	// Seymour's 3-sums need at least four elements on both sides
+	x := findSeparation(g, elms, 3, 4)
+	if x == 0 {
+		// every 3-connected regular matroid other than the pieces has such a 3-separation
+		return nil, errors.New("regular matroid is neither a piece nor a 3-sum")
+	}
+	d.Kind = ThreeSumNode
+	if err := d.glue(x, elms, markers); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// glue() splits d along the k-separation given by the mask x into two children sharing marker elements
+// represented by the non-zero vectors in the intersection of the spans of both sides.
+func (d *Decomposition) glue(x uint64, elms []Element, markers *int) error {
+	g := d.Matroid
+	var xs, ys [][]int
+	for i, e := range elms {
+		if x&(1<<uint(i)) != 0 {
+			xs = append(xs, g.Column(e))
+		} else {
+			ys = append(ys, g.Column(e))
+		}
+	}
+	d.Markers = EmptySet(g.GroundSet().GetType())
+	shared := make(map[string][]int)
+	for _, v := range spanIntersectionGF(xs, ys, g.P) {
+		*markers++
+		mk := Marker{Type: g.GroundSet().GetType(), Id: *markers}
+		d.Markers.Add(mk)
+		shared[mk.Key()] = v
+	}
+	var left, right []Element
+	for i, e := range elms {
+		if x&(1<<uint(i)) != 0 {
+			left = append(left, e)
+		} else {
+			right = append(right, e)
+		}
+	}
+	for _, side := range [][]Element{left, right} {
+		piece := restrictGF(g, side, d.Markers.ToSlice())
+		for k, v := range shared {
+			piece.columns[k] = v
+		}
+		child, err := decompose(piece, markers)
+		if err != nil {
+			return err
+		}
+		d.Children = append(d.Children, child)
+	}
+	return nil
+}
+
+// restrictGF() returns the restriction of g to elms together with the extra elements, whose columns are
+// left for the caller to fill in.
+func restrictGF(g *GFMatroid, elms []Element, extra []Element) *GFMatroid {
+	r := &GFMatroid{
+		P:         g.P,
+		groundSet: EmptySet(g.GroundSet().GetType()),
+		columns:   make(map[string][]int),
+	}
+	for _, e := range elms {
+		r.groundSet.Add(e)
+		r.columns[e.Key()] = g.columns[e.Key()]
+	}
+	for _, e := range extra {
+		r.groundSet.Add(e)
+	}
+	return r
+}
+
+// spanIntersectionGF() returns the non-zero vectors lying in the spans of both xs and ys.
+func spanIntersectionGF(xs, ys [][]int, p int) [][]int {
+	var basis [][]int
+	for _, v := range xs {
+		if rankGF(append(basis[:len(basis):len(basis)], v), p) > len(basis) {
+			basis = append(basis, v)
+		}
+	}
+	ry := rankGF(ys, p)
+	var vs [][]int
+	// enumerate the non-zero combinations of the basis of span(xs)
+	coef := make([]int, len(basis))
+	for {
+		i := 0
+		for i < len(coef) && coef[i] == p-1 {
+			coef[i] = 0
+			i++
+		}
+		if i == len(coef) {
+			return vs
+		}
+		coef[i]++
+		v := make([]int, len(xs[0]))
+		for j, c := range coef {
+			for k := range v {
+				v[k] = (v[k] + c*basis[j][k]) % p
+			}
+		}
+		if rankGF(append(ys[:len(ys):len(ys)], v), p) == ry {
+			vs = append(vs, v)
+		}
+	}
+}
+
+// findSeparation() returns a subset X of elms, as a bit mask, with r(X) + r(E\X) - r(E) = k-1 and
+// at least minSize elements on both sides, or 0 if m has no such exact k-separation.
+// It assumes m has no separations of lower order.
+func findSeparation(m Matroid, elms []Element, k, minSize int) uint64 {
+	n := len(elms)
+	if n < 2*minSize || n > maxRegularSize {
+		return 0
+	}
+	t := m.GroundSet().GetType()
+	full := uint64(1)<<uint(n) - 1
+	if n == 64 {
+		full = ^uint64(0)
+	}
+	rank := m.Rank(m.GroundSet())
+	// fix the first element in X so that each separation is visited once
+	for rest := uint64(0); rest < 1<<uint(n-1); rest++ {
+		x := rest<<1 | 1
+		size := bits.OnesCount64(x)
+		if size < minSize || n-size < minSize {
+			continue
+		}
+		if m.Rank(subsetOf(t, elms, x))+m.Rank(subsetOf(t, elms, full&^x))-rank == k-1 {
+			return x
+		}
+	}
+	return 0
+}
+
+// connectedComponents() returns the connected components of m.
+// Two elements are in the same component if some fundamental circuit with respect to a base contains both.
+func connectedComponents(m Matroid) [][]Element {
+	elms := sortedElements(m.GroundSet())
+	base := greedyBase(m, elms)
+	parent := make(map[string]string)
+	var find func(string) string
+	find = func(k string) string {
+		if p, ok := parent[k]; ok && p != k {
+			parent[k] = find(p)
+			return parent[k]
+		}
+		return k
+	}
+	for _, e := range elms {
+		if base.Contains(e) {
+			continue
+		}
+		for _, b := range sortedElements(base) {
+			base.Swap(e, b)
+			if m.Independent(base) {
+				parent[find(e.Key())] = find(b.Key())
+			}
+			base.Swap(b, e)
+		}
+	}
+	var comps [][]Element
+	index := make(map[string]int)
+	for _, e := range elms {
+		root := find(e.Key())
+		if _, ok := index[root]; !ok {
+			index[root] = len(comps)
+			comps = append(comps, nil)
+		}
+		comps[index[root]] = append(comps[index[root]], e)
+	}
+	return comps
+}
+
+// dualGF() returns a representation over GF(p) of the dual of g, for p = 2.
+// With respect to a base B, g is represented by [I|A] and its dual by [A^T|I].
+func dualGF(g *GFMatroid) *GFMatroid {
+	elms := sortedElements(g.GroundSet())
+	base := greedyBase(g, elms)
+	var others []Element
+	for _, e := range elms {
+		if !base.Contains(e) {
+			others = append(others, e)
+		}
+	}
+	d := restrictGF(g, nil, elms)
+	for _, e := range elms {
+		d.columns[e.Key()] = make([]int, len(others))
+	}
+	for i, e := range others {
+		d.columns[e.Key()][i] = 1
+		for _, b := range sortedElements(base) {
+			base.Swap(e, b)
+			if g.Independent(base) {
+				d.columns[b.Key()][i] = 1
+			}
+			base.Swap(b, e)
+		}
+	}
+	return d
+}
+
+// isGraphicGF() returns true if the 3-connected binary matroid g with at least 4 elements is graphic.
+// The vertex stars of a 3-connected graph are exactly its non-separating cocircuits, so g is graphic
+// if and only if every element lies in exactly two of them and the graph they define represents g.
+func isGraphicGF(g *GFMatroid) bool {
+	elms := sortedElements(g.GroundSet())
+	n := len(elms)
+	if n > maxRegularSize {
+		return false
+	}
+	dim := len(g.Column(elms[0]))
+	// supports of the vectors in the row space
+	supports := make(map[uint64]bool)
+	for y := uint64(1); y < 1<<uint(dim); y++ {
+		var s uint64
+		for i, e := range elms {
+			var dot int
+			for j, v := range g.Column(e) {
+				if y&(1<<uint(j)) != 0 {
+					dot += v
+				}
+			}
+			if dot%2 == 1 {
+				s |= 1 << uint(i)
+			}
+		}
+		if s != 0 {
+			supports[s] = true
+		}
+	}
+	var stars []uint64
+	for s := range supports {
+		minimal := true
+		for t := range supports {
+			if t != s && t&s == t {
+				minimal = false
+				break
+			}
+		}
+		if !minimal {
+			continue
+		}
+		var rest []Element
+		for i, e := range elms {
+			if s&(1<<uint(i)) == 0 {
+				rest = append(rest, e)
+			}
+		}
+		if len(connectedComponents(restrictGF(g, rest, nil))) == 1 {
+			stars = append(stars, s)
+		}
+	}
+
+	columns := make([][]int, n)
+	for i := range elms {
+		columns[i] = make([]int, len(stars))
+		var ends int
+		for v, s := range stars {
+			if s&(1<<uint(i)) != 0 {
+				columns[i][v] = 1
+				ends++
+			}
+		}
+		if ends != 2 {
+			return false
+		}
+	}
+	graph := restrictGF(g, nil, elms)
+	for i, e := range elms {
+		graph.columns[e.Key()] = columns[i]
+	}
+	return sameBases(g, graph, elms, g.Rank(g.GroundSet()))
+}
+
+// r10() returns R10, the vector matroid of the ten vectors of GF(2)^5 with exactly three non-zero entries.
+func r10() *GFMatroid {
+	var elms []Element
+	var columns [][]int
+	for v := 0; v < 1<<5; v++ {
+		if bits.OnesCount(uint(v)) != 3 {
+			continue
+		}
+		c := make([]int, 5)
+		for j := range c {
+			c[j] = v >> uint(j) & 1
+		}
+		elms = append(elms, indexElement(len(elms)))
+		columns = append(columns, c)
+	}
+	g, err := NewGFMatroid(2, elms, columns)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// IsTotallyUnimodular() returns true if every square submatrix of the matrix whose rows are
+// the Vectors of the GroundSet has determinant 0, 1 or -1.
+// Duplicate Vectors do not change the answer, so the GroundSet is used as it is.
+// This checks every square submatrix and is meant for small matrices.
+func (l *LinearMatroid) IsTotallyUnimodular() bool {
+	var rows [][]int64
+	for _, e := range sortedElements(l.GroundSet()) {
+		v := e.(Vector)
+		row := make([]int64, len(v.V))
+		for j, x := range v.V {
+			if x != 0 && x != 1 && x != -1 {
+				return false
+			}
+			row[j] = int64(x)
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return true
+	}
+	// the maximal square submatrices of [I|A] are, up to sign, all square submatrices of A
+	k, d := len(rows), len(rows[0])
+	columns := make([][]int64, 0, k+d)
+	for i := 0; i < k; i++ {
+		c := make([]int64, k)
+		c[i] = 1
+		columns = append(columns, c)
+	}
+	for j := 0; j < d; j++ {
+		c := make([]int64, k)
+		for i := range rows {
+			c[i] = rows[i][j]
+		}
+		columns = append(columns, c)
+	}
+	tu := true
+	eachCombination(k+d, k, func(idx []int) bool {
+		sq := make([][]int64, k)
+		for i, c := range idx {
+			sq[i] = columns[c]
+		}
+		tu = detBareiss(sq).CmpAbs(big.NewInt(1)) <= 0
+		return tu
+	})
+	return tu
+}
+
+// detBareiss() returns the determinant of the square integer matrix a using fraction-free elimination.
+// Intermediate entries are minors of a, which may exceed int64 even for entries in {0, 1, -1}.
+func detBareiss(a [][]int64) *big.Int {
+	n := len(a)
+	m := make([][]*big.Int, n)
+	for i := range a {
+		m[i] = make([]*big.Int, n)
+		for j, v := range a[i] {
+			m[i][j] = big.NewInt(v)
+		}
+	}
+	negate, prev := false, big.NewInt(1)
+	t := new(big.Int)
+	for k := 0; k < n-1; k++ {
+		if m[k][k].Sign() == 0 {
+			swap := -1
+			for i := k + 1; i < n; i++ {
+				if m[i][k].Sign() != 0 {
+					swap = i
+					break
+				}
+			}
+			if swap < 0 {
+				return new(big.Int)
+			}
+			m[k], m[swap] = m[swap], m[k]
+			negate = !negate
+		}
+		for i := k + 1; i < n; i++ {
+			for j := k + 1; j < n; j++ {
+				// the division is exact
+				t.Mul(m[i][k], m[k][j])
+				m[i][j].Mul(m[i][j], m[k][k])
+				m[i][j].Sub(m[i][j], t)
+				m[i][j].Quo(m[i][j], prev)
+			}
+		}
+		prev = m[k][k]
+	}
+	if n == 0 {
+		return big.NewInt(1)
+	}
+	det := new(big.Int).Set(m[n-1][n-1])
+	if negate {
+		det.Neg(det)
+	}
+	return det
+}
@@ -0,0 +1,177 @@
+package matroid
+
+import (
+	"math/big"
+	"testing"
+)
+
+// standardGF() returns the binary matroid represented by [I|A].
+func standardGF(t *testing.T, a [][]int) *GFMatroid {
+	var columns [][]int
+	for i := range a {
+		c := make([]int, len(a))
+		c[i] = 1
+		columns = append(columns, c)
+	}
+	for j := range a[0] {
+		c := make([]int, len(a))
+		for i := range a {
+			c[i] = a[i][j]
+		}
+		columns = append(columns, c)
+	}
+	return newTestGFMatroid(t, 2, columns)
+}
+
+func TestIsRegular(t *testing.T) {
+	var k5 [][]int
+	for i := 0; i < 5; i++ {
+		for j := i + 1; j < 5; j++ {
+			c := make([]int, 5)
+			c[i], c[j] = 1, 1
+			k5 = append(k5, c)
+		}
+	}
+	mk5 := newTestGFMatroid(t, 2, k5)
+	r12 := standardGF(t, [][]int{
+		{1, 1, 1, 0, 0, 0},
+		{1, 1, 0, 1, 0, 0},
+		{1, 0, 0, 0, 1, 0},
+		{0, 1, 0, 0, 0, 1},
+		{0, 0, 1, 0, 1, 1},
+		{0, 0, 0, 1, 1, 1},
+	})
+	tests := []struct {
+		name     string
+		m        Matroid
+		kind     DecompositionKind
+		children []DecompositionKind
+	}{
+		{name: "M(K5)", m: mk5, kind: GraphicPiece},
+		{name: "M*(K5)", m: Dual(mk5), kind: CographicPiece},
+		{name: "R10", m: r10(), kind: R10Piece},
+		{name: "R12", m: r12, kind: ThreeSumNode, children: []DecompositionKind{CographicPiece, GraphicPiece}},
+		{
+			name:     "U(1,2) and U(1,1)",
+			m:        standardGF(t, [][]int{{1}, {0}}),
+			kind:     OneSumNode,
+			children: []DecompositionKind{GraphicPiece, GraphicPiece},
+		},
+		{
+			name:     "4-circuit",
+			m:        standardGF(t, [][]int{{1}, {1}, {1}}),
+			kind:     TwoSumNode,
+			children: []DecompositionKind{GraphicPiece, GraphicPiece},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, minor, err := IsRegular(tt.m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if minor != nil {
+				t.Fatalf("IsRegular() returned an excluded minor %v", minor.Matroid.GroundSet())
+			}
+			if d.Kind != tt.kind {
+				t.Errorf("kind mismatch. expected: %s, actual: %s", tt.kind, d.Kind)
+			}
+			if len(d.Children) != len(tt.children) {
+				t.Fatalf("number of children mismatch. expected: %d, actual: %d", len(tt.children), len(d.Children))
+			}
+			for i, c := range d.Children {
+				if c.Kind != tt.children[i] {
+					t.Errorf("kind of child %d mismatch. expected: %s, actual: %s", i, tt.children[i], c.Kind)
+				}
+			}
+		})
+	}
+
+	t.Run("U(2,4)", func(t *testing.T) {
+		d, minor, err := IsRegular(NewUniformMatroid(newTestSet(type1, 4), 2))
+		if d != nil || minor == nil || err != nil {
+			t.Errorf("IsRegular() = %v, %v, %v, want an excluded minor", d, minor, err)
+		}
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		if _, _, err := IsRegular(NewUniformMatroid(newTestSet(type1, 65), 65)); err == nil {
+			t.Errorf("IsRegular() accepted a ground set of 65 elements")
+		}
+	})
+}
+
+func TestLinearMatroid_IsTotallyUnimodular(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Matrix
+		want bool
+	}{
+		{
+			name: "incidence matrix of a directed graph",
+			m: Matrix{
+				NewUnweightedVector([]float64{1, -1, 0, 0}),
+				NewUnweightedVector([]float64{0, 1, -1, 0}),
+				NewUnweightedVector([]float64{0, 0, 1, -1}),
+				NewUnweightedVector([]float64{-1, 0, 0, 1}),
+				NewUnweightedVector([]float64{1, 0, -1, 0}),
+			},
+			want: true,
+		},
+		{
+			name: "determinant -2",
+			m: Matrix{
+				NewUnweightedVector([]float64{1, 1}),
+				NewUnweightedVector([]float64{1, -1}),
+			},
+			want: false,
+		},
+		{
+			name: "incidence matrix of a triangle",
+			m: Matrix{
+				NewUnweightedVector([]float64{1, 1, 0}),
+				NewUnweightedVector([]float64{0, 1, 1}),
+				NewUnweightedVector([]float64{1, 0, 1}),
+			},
+			want: false,
+		},
+		{
+			name: "entry 2",
+			m: Matrix{
+				NewUnweightedVector([]float64{2, 0}),
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewLinearMatroid(tt.m).IsTotallyUnimodular(); got != tt.want {
+				t.Errorf("LinearMatroid.IsTotallyUnimodular() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetBareiss(t *testing.T) {
+	// the Sylvester Hadamard matrix of order 32 has determinant ±2^80, beyond int64
+	h := [][]int64{{1}}
+	for len(h) < 32 {
+		n := len(h)
+		next := make([][]int64, 2*n)
+		for i := range next {
+			next[i] = make([]int64, 2*n)
+			for j := range next[i] {
+				v := h[i%n][j%n]
+				if i >= n && j >= n {
+					v = -v
+				}
+				next[i][j] = v
+			}
+		}
+		h = next
+	}
+	expected := new(big.Int).Lsh(big.NewInt(1), 80)
+	if actual := new(big.Int).Abs(detBareiss(h)); actual.Cmp(expected) != 0 {
+		t.Errorf("determinant mismatch. expected: %s, actual: %s", expected, actual)
+	}
+}
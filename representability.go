@@ -0,0 +1,265 @@
+package matroid
+
+// Minor is the minor m / Contracted \ Deleted of a matroid m.
+type Minor struct {
+	Contracted *Set
+	Deleted    *Set
+	// Matroid is the minor itself, on the GroundSet of m minus Contracted and Deleted.
+	Matroid Matroid
+}
+
+// IsBinary() returns a representation of m over GF(2) on the same GroundSet if m is binary.
+// Otherwise it returns a minor of m isomorphic to U(2,4), the only excluded minor of binary matroids.
+// Verifying a representation takes one oracle call per subset of the rank of m,
+// so this is meant for small ground sets.
+func IsBinary(m Matroid) (*GFMatroid, *Minor) {
+	return representOrExclude(m, 2)
+}
+
+// IsTernary() returns a representation of m over GF(3) on the same GroundSet if m is ternary.
+// Otherwise it returns a minor of m isomorphic to one of the excluded minors of ternary matroids:
+// U(2,5), U(3,5), the Fano plane F7 or its dual.
+func IsTernary(m Matroid) (*GFMatroid, *Minor) {
+	return representOrExclude(m, 3)
+}
+
+func representOrExclude(m Matroid, p int) (*GFMatroid, *Minor) {
+	if g := represent(m, p); g != nil {
+		return g, nil
+	}
+	return nil, excludedMinor(m, p)
+}
+
+// represent() returns a representation of m over GF(p) for p = 2 or 3, or nil if there is none.
+// Over these fields a representation is unique up to scaling rows and columns, so it is enough
+// to build the standard representation [I|A] with respect to a base and verify it.
+func represent(m Matroid, p int) *GFMatroid {
+	gs := m.GroundSet()
+	elms := sortedElements(gs)
+	base := greedyBase(m, elms)
+	r := base.Cardinality()
+
+	row := make(map[string]int)
+	var others []Element
+	for _, e := range elms {
+		if base.Contains(e) {
+			row[e.Key()] = len(row)
+		} else {
+			others = append(others, e)
+		}
+	}
+
+	columns := make([][]int, len(elms))
+	col := make(map[string]int)
+	for i, e := range elms {
+		columns[i] = make([]int, r)
+		col[e.Key()] = i
+		if j, ok := row[e.Key()]; ok {
+			columns[i][j] = 1
+		}
+	}
+	// the support of the column of e is its fundamental circuit with respect to base.
+	var support [][2]int
+	for _, e := range others {
+		for _, b := range sortedElements(base) {
+			base.Swap(e, b)
+			if m.Independent(base) {
+				support = append(support, [2]int{row[b.Key()], col[e.Key()]})
+			}
+			base.Swap(b, e)
+		}
+	}
+	if p == 2 {
+		for _, s := range support {
+			columns[s[1]][s[0]] = 1
+		}
+	} else if !signSupport(m, base, elms, columns, support, p) {
+		return nil
+	}
+
+	g := &GFMatroid{
+		P:         p,
+		groundSet: gs.Clone(),
+		columns:   make(map[string][]int),
+	}
+	for i, e := range elms {
+		g.columns[e.Key()] = columns[i]
+	}
+	if !sameBases(m, g, elms, r) {
+		return nil
+	}
+	return g
+}
+
+// signSupport() fills the entries of the support of [I|A] with non-zero values of GF(p).
+// Entries on a spanning forest of the bipartite support graph are normalized to 1; every other
+// entry closes a chordless cycle whose determinant must vanish exactly when the corresponding
+// set is dependent in m, which fixes the entry. It returns false if no value is consistent.
+func signSupport(m Matroid, base *Set, elms []Element, columns [][]int, support [][2]int, p int) bool {
+	r := base.Cardinality()
+	// nodes 0..r-1 are rows and r.. are columns
+	node := func(s [2]int) (int, int) { return s[0], r + s[1] }
+	adj := make(map[int][]int)
+	fixed := make(map[[2]int]bool)
+	addFixed := func(s [2]int) {
+		u, v := node(s)
+		adj[u] = append(adj[u], v)
+		adj[v] = append(adj[v], u)
+		fixed[s] = true
+	}
+	// bfs() returns the predecessors of nodes reachable from u over fixed entries
+	bfs := func(u int) map[int]int {
+		prev := map[int]int{u: u}
+		queue := []int{u}
+		for len(queue) > 0 {
+			x := queue[0]
+			queue = queue[1:]
+			for _, y := range adj[x] {
+				if _, ok := prev[y]; !ok {
+					prev[y] = x
+					queue = append(queue, y)
+				}
+			}
+		}
+		return prev
+	}
+
+	// spanning forest
+	for _, s := range support {
+		u, v := node(s)
+		if _, ok := bfs(u)[v]; !ok {
+			columns[s[1]][s[0]] = 1
+			addFixed(s)
+		}
+	}
+
+	rows := sortedElements(base)
+	for len(fixed) < len(support) {
+		// the unfixed entry with the shortest path between its endpoints has no unfixed chords
+		var next [2]int
+		var path []int
+		for _, s := range support {
+			if fixed[s] {
+				continue
+			}
+			u, v := node(s)
+			prev := bfs(u)
+			var pt []int
+			for x := v; x != u; x = prev[x] {
+				pt = append(pt, x)
+			}
+			pt = append(pt, u)
+			if path == nil || len(pt) < len(path) {
+				next, path = s, pt
+			}
+		}
+
+		sub := base.Clone()
+		var rs, cs []int
+		for _, x := range path {
+			if x < r {
+				rs = append(rs, x)
+				sub.Remove(rows[x])
+			} else {
+				cs = append(cs, x-r)
+				sub.Add(elms[x-r])
+			}
+		}
+		isBase := m.Independent(sub)
+		found := false
+		for x := 1; x < p; x++ {
+			columns[next[1]][next[0]] = x
+			sq := make([][]int, len(cs))
+			for i, c := range cs {
+				sq[i] = make([]int, len(rs))
+				for j, rr := range rs {
+					sq[i][j] = columns[c][rr]
+				}
+			}
+			if (detGF(sq, p) != 0) == isBase {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+		addFixed(next)
+	}
+	return true
+}
+
+// sameBases() returns true if m1 and m2, both on the elements elms and of rank r, have the same bases.
+func sameBases(m1, m2 Matroid, elms []Element, r int) bool {
+	if m2.Rank(m2.GroundSet()) != r {
+		return false
+	}
+	t := m1.GroundSet().GetType()
+	same := true
+	eachCombination(len(elms), r, func(idx []int) bool {
+		s := EmptySet(t)
+		for _, i := range idx {
+			s.Add(elms[i])
+		}
+		same = m1.Independent(s) == m2.Independent(s)
+		return same
+	})
+	return same
+}
+
+// eachCombination() calls f with every k-subset of 0, ..., n-1 in lexicographic order until f returns false.
+func eachCombination(n, k int, f func([]int) bool) {
+	if k > n || k < 0 {
+		return
+	}
+	idx := make([]int, k)
+	for i := range idx {
+		idx[i] = i
+	}
+	for {
+		if !f(idx) {
+			return
+		}
+		i := k - 1
+		for i >= 0 && idx[i] == n-k+i {
+			i--
+		}
+		if i < 0 {
+			return
+		}
+		idx[i]++
+		for j := i + 1; j < k; j++ {
+			idx[j] = idx[j-1] + 1
+		}
+	}
+}
+
+// excludedMinor() deletes or contracts elements of m, which is not representable over GF(p),
+// as long as the result stays non-representable. The result is a minor-minimal non-representable minor.
+func excludedMinor(m Matroid, p int) *Minor {
+	t := m.GroundSet().GetType()
+	contracted, deleted := EmptySet(t), EmptySet(t)
+	var cur Matroid = m
+	for shrunk := true; shrunk; {
+		shrunk = false
+		for _, e := range sortedElements(cur.GroundSet()) {
+			deleted.Add(e)
+			if next := newMinor(m, contracted, deleted); represent(next, p) == nil {
+				cur, shrunk = next, true
+				break
+			}
+			deleted.Remove(e)
+			contracted.Add(e)
+			if next := newMinor(m, contracted, deleted); represent(next, p) == nil {
+				cur, shrunk = next, true
+				break
+			}
+			contracted.Remove(e)
+		}
+	}
+	return &Minor{
+		Contracted: contracted,
+		Deleted:    deleted,
+		Matroid:    cur,
+	}
+}
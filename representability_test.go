@@ -0,0 +1,66 @@
+package matroid
+
+import (
+	"testing"
+)
+
+func TestIsBinary(t *testing.T) {
+	fano := newTestGFMatroid(t, 2, [][]int{
+		{1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {1, 1, 0}, {1, 0, 1}, {0, 1, 1}, {1, 1, 1},
+	})
+	u24 := NewUniformMatroid(newTestSet(type2, 4), 2)
+	tests := []struct {
+		name    string
+		m       Matroid
+		binary  bool
+		ternary bool
+		// size of the excluded minor for ternary matroids
+		minorSize int
+	}{
+		{name: "U(2,4)", m: u24, binary: false, ternary: true},
+		{name: "U(3,6)", m: NewUniformMatroid(newTestSet(type1, 6), 3), binary: false, ternary: false, minorSize: 5},
+		{name: "U(2,5)", m: NewUniformMatroid(newTestSet(type1, 5), 2), binary: false, ternary: false, minorSize: 5},
+		{name: "Fano", m: fano, binary: true, ternary: false, minorSize: 7},
+		{name: "dual of Fano", m: Dual(fano), binary: true, ternary: false, minorSize: 7},
+		{
+			name: "M(K4) from signed incidence vectors",
+			m: NewLinearMatroid(Matrix{
+				NewUnweightedVector([]float64{1, -1, 0, 0}),
+				NewUnweightedVector([]float64{1, 0, -1, 0}),
+				NewUnweightedVector([]float64{1, 0, 0, -1}),
+				NewUnweightedVector([]float64{0, 1, -1, 0}),
+				NewUnweightedVector([]float64{0, 1, 0, -1}),
+				NewUnweightedVector([]float64{0, 0, 1, -1}),
+			}),
+			binary:  true,
+			ternary: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, minor := IsBinary(tt.m)
+			if (g != nil) != tt.binary || (minor != nil) == tt.binary {
+				t.Fatalf("IsBinary() = %v, %v, want binary %v", g, minor, tt.binary)
+			}
+			if g != nil && !sameBases(tt.m, g, sortedElements(tt.m.GroundSet()), tt.m.Rank(tt.m.GroundSet())) {
+				t.Errorf("IsBinary() returned a representation of another matroid")
+			}
+			if minor != nil {
+				if _, ok, _ := Isomorphic(minor.Matroid, u24); !ok {
+					t.Errorf("IsBinary() returned a minor not isomorphic to U(2,4): %v", minor.Matroid.GroundSet())
+				}
+			}
+
+			g, minor = IsTernary(tt.m)
+			if (g != nil) != tt.ternary || (minor != nil) == tt.ternary {
+				t.Fatalf("IsTernary() = %v, %v, want ternary %v", g, minor, tt.ternary)
+			}
+			if g != nil && !sameBases(tt.m, g, sortedElements(tt.m.GroundSet()), tt.m.Rank(tt.m.GroundSet())) {
+				t.Errorf("IsTernary() returned a representation of another matroid")
+			}
+			if minor != nil && minor.Matroid.GroundSet().Cardinality() != tt.minorSize {
+				t.Errorf("IsTernary() returned a minor of size %d", minor.Matroid.GroundSet().Cardinality())
+			}
+		})
+	}
+}
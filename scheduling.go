@@ -0,0 +1,123 @@
+package matroid
+
+import (
+	"fmt"
+	"sort"
+)
+
+const JobType ElementType = "JOB"
+
+// Job is a unit-time job which must finish by its Deadline to earn its Profit.
+// Time slots are numbered 1, 2, ..., and slot t ends at time t, so a job fits in any slot up to its Deadline.
+type Job struct {
+	Id       int64
+	Deadline int
+	Profit   float64
+}
+
+func (j *Job) GetType() ElementType {
+	return JobType
+}
+
+func (j *Job) Key() string {
+	return fmt.Sprintf("%d", j.Id)
+}
+
+func (j *Job) Value() interface{} {
+	return j.Id
+}
+
+func (j *Job) Weight() float64 {
+	return j.Profit
+}
+
+// SchedulingMatroid is the matroid on Jobs in which a set is independent
+// if all of its jobs can be scheduled on one machine before their deadlines.
+type SchedulingMatroid struct {
+	groundSet *Set
+}
+
+func (sm *SchedulingMatroid) GroundSet() *Set {
+	return sm.groundSet
+}
+
+// Rank() returns the maximum number of jobs of s that meet their deadlines.
+// After sorting by deadline, the number of jobs that fit in the first d slots is
+// c = min(c+1, d) at each job with deadline d, which takes O(n log n) time.
+func (sm *SchedulingMatroid) Rank(s *Set) int {
+	var c int
+	for _, j := range sortedJobs(s) {
+		c = min(c+1, max(j.Deadline, 0))
+	}
+	return c
+}
+
+// Independent() returns true if the i-th job of s by deadline has a deadline at least i for every i.
+func (sm *SchedulingMatroid) Independent(s *Set) bool {
+	for i, j := range sortedJobs(s) {
+		if j.Deadline < i+1 {
+			return false
+		}
+	}
+	return true
+}
+
+// Assign() returns a time slot for each job of s, keyed by Key(), such that every job meets its deadline.
+// Jobs are assigned in order of deadline, which succeeds if and only if s is independent.
+func (sm *SchedulingMatroid) Assign(s *Set) (map[string]int, error) {
+	slots := make(map[string]int)
+	for i, j := range sortedJobs(s) {
+		if j.Deadline < i+1 {
+			return nil, fmt.Errorf("job %s cannot meet its deadline %d", j.Key(), j.Deadline)
+		}
+		slots[j.Key()] = i + 1
+	}
+	return slots, nil
+}
+
+// OptimalSchedule() returns a set of jobs of maximum total profit that meet their deadlines,
+// together with a time slot for each of them. Jobs are added greedily by decreasing profit,
+// and jobs with non-positive profit are never scheduled.
+func (sm *SchedulingMatroid) OptimalSchedule() (*Set, map[string]int) {
+	var jobs []Element
+	for _, e := range sortedElements(sm.groundSet) {
+		if e.Weight() > 0 {
+			jobs = append(jobs, e)
+		}
+	}
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].Weight() > jobs[j].Weight()
+	})
+	s := greedyBase(sm, jobs)
+	slots, err := sm.Assign(s)
+	// greedyBase() returns an independent set
+	if err != nil {
+		panic(err)
+	}
+	return s, slots
+}
+
+// NewSchedulingMatroid() returns the scheduling matroid on the given jobs.
+// Every job must have a unique Id.
+func NewSchedulingMatroid(jobs []*Job) (*SchedulingMatroid, error) {
+	gs := EmptySet(JobType)
+	for _, j := range jobs {
+		if !gs.Add(j) {
+			return nil, fmt.Errorf("duplicate job %s", j.Key())
+		}
+	}
+	return &SchedulingMatroid{groundSet: gs}, nil
+}
+
+// sortedJobs() returns the jobs of s sorted by deadline, ties broken by Key().
+func sortedJobs(s *Set) []*Job {
+	elms := sortedElements(s)
+	jobs := make([]*Job, len(elms))
+	for i, e := range elms {
+		jobs[i] = e.(*Job)
+	}
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].Deadline < jobs[j].Deadline
+	})
+	return jobs
+}
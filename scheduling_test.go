@@ -0,0 +1,57 @@
+package matroid
+
+import (
+	"testing"
+)
+
+func TestSchedulingMatroid(t *testing.T) {
+	jobs := []*Job{
+		{Id: 1, Deadline: 2, Profit: 100},
+		{Id: 2, Deadline: 1, Profit: 19},
+		{Id: 3, Deadline: 2, Profit: 27},
+		{Id: 4, Deadline: 1, Profit: 25},
+		{Id: 5, Deadline: 3, Profit: 15},
+		{Id: 6, Deadline: 0, Profit: 50},
+	}
+	sm, err := NewSchedulingMatroid(jobs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	elms := sortedElements(sm.GroundSet())
+
+	// the rank is the largest size of a subset which can be assigned slots in some order
+	for mask := uint64(0); mask < 1<<uint(len(elms)); mask++ {
+		s := subsetOf(JobType, elms, mask)
+		expected := 0
+		for sub := mask; ; sub = (sub - 1) & mask {
+			if _, err := sm.Assign(subsetOf(JobType, elms, sub)); err == nil {
+				expected = max(expected, subsetOf(JobType, elms, sub).Cardinality())
+			}
+			if sub == 0 {
+				break
+			}
+		}
+		if actual := sm.Rank(s); actual != expected {
+			t.Errorf("rank mismatch for %b. expected: %d, actual: %d", mask, expected, actual)
+		}
+		if sm.Independent(s) != (expected == s.Cardinality()) {
+			t.Errorf("independence mismatch for %b", mask)
+		}
+	}
+
+	s, slots := sm.OptimalSchedule()
+	var profit float64
+	for e := range s.Iter() {
+		profit += e.Weight()
+		if slots[e.Key()] > e.(*Job).Deadline {
+			t.Errorf("job %s is late", e.Key())
+		}
+	}
+	if profit != 142 {
+		t.Errorf("profit mismatch. expected: %f, actual: %f", 142.0, profit)
+	}
+
+	if _, err := NewSchedulingMatroid([]*Job{{Id: 1}, {Id: 1}}); err == nil {
+		t.Error("expected an error for duplicate jobs")
+	}
+}
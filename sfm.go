@@ -0,0 +1,255 @@
+package matroid
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ErrNotConverged is returned by MinimizeSubmodular() if Wolfe's algorithm stops, due to rounding errors
+// or its iteration bound, at a point that is not the minimum-norm point.
+var ErrNotConverged = errors.New("minimum-norm point algorithm did not converge")
+
+// bruteForceFallbackSize is the largest ground set on which MinimizeSubmodular() falls back to
+// evaluating f on every subset when Wolfe's algorithm does not converge.
+const bruteForceFallbackSize = 16
+
+// MinimizeSubmodular() returns a set minimizing the submodular function f and the minimum.
+// It finds the minimum-norm point x of the base polyhedron of f - f(∅) by Wolfe's algorithm
+// (the Fujishige–Wolfe algorithm); the elements with negative coordinates of x form a minimizer.
+// To absorb rounding errors the best set among {e : x_e <= t} over all thresholds t is returned.
+// Optimality of x is checked up to a relative tolerance of 1e-12. If Wolfe's algorithm does not converge,
+// small ground sets are minimized by brute force; on larger ones the best set found is returned
+// together with ErrNotConverged.
+func MinimizeSubmodular(f SubmodularFunction) (*Set, float64, error) {
+	gs := f.GroundSet()
+	elms := sortedElements(gs)
+	if len(elms) == 0 {
+		return EmptySet(gs.GetType()), f.Value(EmptySet(gs.GetType())), nil
+	}
+	x, converged := minimumNormPoint(f, elms)
+
+	order := make([]int, len(elms))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return x[order[i]] < x[order[j]]
+	})
+	s := EmptySet(gs.GetType())
+	best, value := s.Clone(), f.Value(s)
+	for _, i := range order {
+		s.Add(elms[i])
+		if v := f.Value(s); v < value-submodularTolerance {
+			best, value = s.Clone(), v
+		}
+	}
+	if !converged {
+		if len(elms) <= bruteForceFallbackSize {
+			best, value = bruteForceMinimize(f)
+			return best, value, nil
+		}
+		return best, value, ErrNotConverged
+	}
+	return best, value, nil
+}
+
+// MinimizeSubmodularChecked() works like MinimizeSubmodular() and verifies the minimum
+// by evaluating f on every subset of the GroundSet, which is only feasible for small ground sets.
+// It returns an error if the two minima differ.
+func MinimizeSubmodularChecked(f SubmodularFunction) (*Set, float64, error) {
+	s, v, err := MinimizeSubmodular(f)
+	if err != nil {
+		return s, v, err
+	}
+	_, expected := bruteForceMinimize(f)
+	if math.Abs(v-expected) > submodularTolerance*math.Max(1, math.Abs(expected)) {
+		return s, v, fmt.Errorf("minimum %g differs from %g found by brute force", v, expected)
+	}
+	return s, v, nil
+}
+
+// minimumNormPoint() returns the point of minimum Euclidean norm in the base polyhedron of f - f(∅)
+// by Wolfe's algorithm, with coordinates indexed as elms, and whether it converged. Linear optimization
+// over the base polyhedron is the greedy algorithm, and the current point is kept as a convex
+// combination of a corral, a set of affinely independent vertices. If the algorithm stops early,
+// the current point is returned and checked for optimality.
+func minimumNormPoint(f SubmodularFunction, elms []Element) ([]float64, bool) {
+	n := len(elms)
+	ascending := make([]int, n)
+	for i := range ascending {
+		ascending[i] = i
+	}
+	corral := [][]float64{greedyVertexOf(f, elms, ascending)}
+	lambda := []float64{1}
+	x := append([]float64(nil), corral[0]...)
+
+	// Wolfe's algorithm terminates in finitely many steps; the bound guards against rounding errors.
+	for iter := 0; iter < 100*(n+1)*(n+1); iter++ {
+		q := wolfeVertex(f, elms, x)
+		if wolfeOptimal(x, q) {
+			return x, true
+		}
+		corral = append(corral, q)
+		lambda = append(lambda, 0)
+
+		for {
+			alpha, ok := affineMinimizer(corral)
+			if !ok {
+				return x, wolfeOptimal(x, wolfeVertex(f, elms, x))
+			}
+			interior := true
+			for _, a := range alpha {
+				if a <= 1e-12 {
+					interior = false
+				}
+			}
+			if interior {
+				lambda = alpha
+				x = combine(corral, lambda)
+				break
+			}
+			// move from x towards the affine minimizer until a coefficient vanishes
+			theta := 1.0
+			for i, a := range alpha {
+				if a <= 1e-12 && lambda[i]-a > 0 {
+					theta = math.Min(theta, lambda[i]/(lambda[i]-a))
+				}
+			}
+			var nextCorral [][]float64
+			var nextLambda []float64
+			for i := range corral {
+				l := theta*alpha[i] + (1-theta)*lambda[i]
+				if l > 1e-12 {
+					nextCorral = append(nextCorral, corral[i])
+					nextLambda = append(nextLambda, l)
+				}
+			}
+			if len(nextCorral) == 0 {
+				return x, wolfeOptimal(x, wolfeVertex(f, elms, x))
+			}
+			corral, lambda = nextCorral, nextLambda
+			x = combine(corral, lambda)
+		}
+	}
+	return x, wolfeOptimal(x, wolfeVertex(f, elms, x))
+}
+
+// wolfeVertex() returns the vertex q of the base polyhedron minimizing <x, q>, by the greedy algorithm
+// in increasing order of x.
+func wolfeVertex(f SubmodularFunction, elms []Element, x []float64) []float64 {
+	order := make([]int, len(elms))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return x[order[i]] < x[order[j]]
+	})
+	return greedyVertexOf(f, elms, order)
+}
+
+// wolfeOptimal() returns true if x is the minimum-norm point, that is <x, x> <= <x, q> for the vertex q
+// minimizing <x, q>, up to a relative tolerance.
+func wolfeOptimal(x, q []float64) bool {
+	return dot(x, x)-dot(x, q) <= 1e-12*math.Max(1, dot(q, q))
+}
+
+// greedyVertexOf() returns the vertex of the base polyhedron of f - f(∅) for the ordering of elms given by order.
+func greedyVertexOf(f SubmodularFunction, elms []Element, order []int) []float64 {
+	q := make([]float64, len(elms))
+	s := EmptySet(f.GroundSet().GetType())
+	prev := f.Value(s)
+	for _, i := range order {
+		s.Add(elms[i])
+		v := f.Value(s)
+		q[i] = v - prev
+		prev = v
+	}
+	return q
+}
+
+// affineMinimizer() returns the coefficients, summing to one, of the point of minimum norm
+// in the affine hull of the given points. It returns false if the points are affinely dependent.
+func affineMinimizer(points [][]float64) ([]float64, bool) {
+	k := len(points)
+	// [P^T P 1; 1^T 0] [alpha; mu] = [0; 1]
+	a := make([][]float64, k+1)
+	for i := range a {
+		a[i] = make([]float64, k+2)
+		for j := 0; j < k; j++ {
+			if i < k {
+				a[i][j] = dot(points[i], points[j])
+			} else {
+				a[i][j] = 1
+			}
+		}
+		if i < k {
+			a[i][k] = 1
+		}
+	}
+	a[k][k+1] = 1
+	sol, ok := solveLinear(a)
+	if !ok {
+		return nil, false
+	}
+	return sol[:k], true
+}
+
+// solveLinear() solves the square linear system whose augmented matrix is a by Gaussian elimination
+// with partial pivoting. a is overwritten. It returns false if the system is singular.
+func solveLinear(a [][]float64) ([]float64, bool) {
+	n := len(a)
+	var scale float64
+	for i := range a {
+		for j := 0; j < n; j++ {
+			scale = math.Max(scale, math.Abs(a[i][j]))
+		}
+	}
+	for c := 0; c < n; c++ {
+		pivot := c
+		for r := c + 1; r < n; r++ {
+			if math.Abs(a[r][c]) > math.Abs(a[pivot][c]) {
+				pivot = r
+			}
+		}
+		if math.Abs(a[pivot][c]) <= 1e-12*scale {
+			return nil, false
+		}
+		a[c], a[pivot] = a[pivot], a[c]
+		for r := c + 1; r < n; r++ {
+			f := a[r][c] / a[c][c]
+			for k := c; k <= n; k++ {
+				a[r][k] -= f * a[c][k]
+			}
+		}
+	}
+	x := make([]float64, n)
+	for r := n - 1; r >= 0; r-- {
+		v := a[r][n]
+		for k := r + 1; k < n; k++ {
+			v -= a[r][k] * x[k]
+		}
+		x[r] = v / a[r][r]
+	}
+	return x, true
+}
+
+// combine() returns the linear combination of points with the given coefficients.
+func combine(points [][]float64, coefficients []float64) []float64 {
+	x := make([]float64, len(points[0]))
+	for i, p := range points {
+		for j := range x {
+			x[j] += coefficients[i] * p[j]
+		}
+	}
+	return x
+}
+
+func dot(x, y []float64) float64 {
+	var d float64
+	for i := range x {
+		d += x[i] * y[i]
+	}
+	return d
+}
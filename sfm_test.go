@@ -0,0 +1,84 @@
+package matroid
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestMinimizeSubmodular(t *testing.T) {
+	gs := newTestSet(type1, 8)
+	elms := sortedElements(gs)
+	index := make(map[string]int)
+	for i, e := range elms {
+		index[e.Key()] = i
+	}
+	rnd := rand.New(rand.NewSource(1))
+	modular := func() []float64 {
+		w := make([]float64, len(elms))
+		for i := range w {
+			w[i] = rnd.Float64()*4 - 2
+		}
+		return w
+	}
+	sum := func(w []float64, s *Set) float64 {
+		var v float64
+		for _, e := range s.ToSlice() {
+			v += w[index[e.Key()]]
+		}
+		return v
+	}
+
+	var functions []SubmodularFunction
+	for k := 0; k < 5; k++ {
+		// a cut function of a random graph plus a modular function
+		var edges [][3]float64
+		for i := range elms {
+			for j := i + 1; j < len(elms); j++ {
+				if rnd.Intn(3) == 0 {
+					edges = append(edges, [3]float64{float64(i), float64(j), rnd.Float64()})
+				}
+			}
+		}
+		w := modular()
+		functions = append(functions, NewSubmodularFunction(gs, func(s *Set) float64 {
+			v := sum(w, s)
+			for _, e := range edges {
+				if s.Contains(elms[int(e[0])]) != s.Contains(elms[int(e[1])]) {
+					v += e[2]
+				}
+			}
+			return v
+		}))
+		// a concave function of the cardinality minus a modular function
+		c, w2 := rnd.Float64()*3, modular()
+		functions = append(functions, NewSubmodularFunction(gs, func(s *Set) float64 {
+			return c*math.Sqrt(float64(s.Cardinality())) - sum(w2, s)
+		}))
+	}
+	// the rank function of a uniform matroid minus a modular function
+	u := RankFunction(NewUniformMatroid(gs, 3))
+	w3 := modular()
+	functions = append(functions, NewSubmodularFunction(gs, func(s *Set) float64 {
+		return u.Value(s) - sum(w3, s)
+	}))
+
+	for i, f := range functions {
+		s, v, err := MinimizeSubmodularChecked(f)
+		if err != nil {
+			t.Errorf("function %d: %v", i, err)
+		}
+		if math.Abs(f.Value(s)-v) > submodularTolerance {
+			t.Errorf("function %d: value mismatch. expected: %f, actual: %f", i, f.Value(s), v)
+		}
+		// the brute-force fallback must not hide a failure of Wolfe's algorithm
+		if _, converged := minimumNormPoint(f, elms); !converged {
+			t.Errorf("function %d: minimum-norm point did not converge", i)
+		}
+	}
+
+	empty := NewSubmodularFunction(EmptySet(type1), func(*Set) float64 { return 2 })
+	if s, v, err := MinimizeSubmodular(empty); err != nil || !s.IsEmpty() || v != 2 {
+		t.Errorf("empty ground set mismatch. expected: %f, actual: %f", 2.0, v)
+	}
+}
@@ -0,0 +1,184 @@
+package matroid
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// SubmodularFunction is a real-valued set function on the subsets of a GroundSet satisfying
+// f(X) + f(Y) >= f(X ∪ Y) + f(X ∩ Y). The rank function of a Matroid is an integer-valued one.
+type SubmodularFunction interface {
+	GroundSet() *Set
+	// Value() is the value oracle of the function.
+	// Make sure that input Set must be a subset of GroundSet.
+	Value(*Set) float64
+}
+
+// submodularTolerance is the absolute error allowed when comparing values of real submodular functions.
+const submodularTolerance = 1e-9
+
+type setFunction struct {
+	groundSet *Set
+	f         func(*Set) float64
+}
+
+func (sf *setFunction) GroundSet() *Set {
+	return sf.groundSet
+}
+
+func (sf *setFunction) Value(s *Set) float64 {
+	return sf.f(s)
+}
+
+// NewSubmodularFunction() returns the set function f on the subsets of gs.
+// Submodularity of f is not checked.
+func NewSubmodularFunction(gs *Set, f func(*Set) float64) SubmodularFunction {
+	return &setFunction{
+		groundSet: gs,
+		f:         f,
+	}
+}
+
+// RankFunction() returns the rank function of m as a SubmodularFunction.
+func RankFunction(m Matroid) SubmodularFunction {
+	return &setFunction{
+		groundSet: m.GroundSet(),
+		f: func(s *Set) float64 {
+			return float64(m.Rank(s))
+		},
+	}
+}
+
+// GreedyVertex() returns the vertex of the base polyhedron of f given by the ordering of the GroundSet:
+// the i-th element of order gets f(S_i) - f(S_{i-1}), where S_i consists of the first i elements.
+// The result is keyed by Key().
+func GreedyVertex(f SubmodularFunction, order []Element) map[string]float64 {
+	x := make(map[string]float64, len(order))
+	s := EmptySet(f.GroundSet().GetType())
+	prev := f.Value(s)
+	for _, e := range order {
+		s.Add(e)
+		v := f.Value(s)
+		x[e.Key()] = v - prev
+		prev = v
+	}
+	return x
+}
+
+// Polymatroid is the polyhedron P(f) = {x >= 0 : x(S) <= f(S) for every S} of a polymatroid rank function f,
+// a submodular function that is monotone and vanishes on the empty set.
+// Its maximal points form the base polyhedron B(f) = {x in P(f) : x(E) = f(E)}.
+// Vectors are maps from Key() of elements to coordinates, and missing keys are zero.
+type Polymatroid struct {
+	f        SubmodularFunction
+	elements []Element
+}
+
+// Function() returns the rank function of the polymatroid.
+func (p *Polymatroid) Function() SubmodularFunction {
+	return p.f
+}
+
+// Greedy() returns a point of P(f) maximizing the weighted sum of coordinates, and the maximum.
+// Elements of positive weight are taken in decreasing order of weight and given their marginal values;
+// the others get zero.
+func (p *Polymatroid) Greedy(w map[string]float64) (map[string]float64, float64) {
+	var order []Element
+	for _, e := range p.elements {
+		if w[e.Key()] > 0 {
+			order = append(order, e)
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return w[order[i].Key()] > w[order[j].Key()]
+	})
+	x := GreedyVertex(p.f, order)
+	var value float64
+	for _, e := range p.elements {
+		value += w[e.Key()] * x[e.Key()]
+	}
+	return x, value
+}
+
+// Separate() returns a set S minimizing f(S) - x(S) and the minimum, found by MinimizeSubmodular().
+// x is in P(f) if and only if it is non-negative and the minimum is non-negative;
+// otherwise S is a most violated constraint x(S) <= f(S).
+// It returns ErrNotConverged if MinimizeSubmodular() does not converge.
+func (p *Polymatroid) Separate(x map[string]float64) (*Set, float64, error) {
+	gs := p.f.GroundSet()
+	return MinimizeSubmodular(NewSubmodularFunction(gs, func(s *Set) float64 {
+		return p.f.Value(s) - sumOver(x, s)
+	}))
+}
+
+// Contains() returns true if x is in P(f). Constraints violated by at most 1e-9 are considered satisfied.
+// It returns ErrNotConverged if Separate() does not converge.
+func (p *Polymatroid) Contains(x map[string]float64) (bool, error) {
+	for _, e := range p.elements {
+		if x[e.Key()] < -submodularTolerance {
+			return false, nil
+		}
+	}
+	_, v, err := p.Separate(x)
+	if err != nil {
+		return false, err
+	}
+	return v > -submodularTolerance, nil
+}
+
+// InBasePolyhedron() returns true if x is in B(f), the face of P(f) where x(E) = f(E),
+// with the same tolerance as Contains(). It returns ErrNotConverged if Separate() does not converge.
+func (p *Polymatroid) InBasePolyhedron(x map[string]float64) (bool, error) {
+	gs := p.f.GroundSet()
+	if math.Abs(sumOver(x, gs)-p.f.Value(gs)) > submodularTolerance {
+		return false, nil
+	}
+	return p.Contains(x)
+}
+
+// NewPolymatroid() returns the polymatroid of f. f must vanish on the empty set;
+// monotonicity and submodularity are not checked.
+func NewPolymatroid(f SubmodularFunction) (*Polymatroid, error) {
+	if v := f.Value(EmptySet(f.GroundSet().GetType())); math.Abs(v) > submodularTolerance {
+		return nil, errors.New("a polymatroid rank function must vanish on the empty set")
+	}
+	return &Polymatroid{
+		f:        f,
+		elements: sortedElements(f.GroundSet()),
+	}, nil
+}
+
+// PolymatroidOf() returns the polymatroid of the rank function of m, whose vertices are the
+// incidence vectors of independent sets of m.
+func PolymatroidOf(m Matroid) *Polymatroid {
+	p, _ := NewPolymatroid(RankFunction(m))
+	return p
+}
+
+// sumOver() returns the sum of the coordinates of x over s.
+func sumOver(x map[string]float64, s *Set) float64 {
+	var sum float64
+	for _, e := range s.ToSlice() {
+		sum += x[e.Key()]
+	}
+	return sum
+}
+
+// bruteForceMinimize() returns a minimizer of f and the minimum by evaluating f on every subset.
+// Among minimizers it returns the first one found in order of bit masks over sortedElements().
+func bruteForceMinimize(f SubmodularFunction) (*Set, float64) {
+	gs := f.GroundSet()
+	elms := sortedElements(gs)
+	if len(elms) > 63 {
+		panic("ground set is too large to enumerate")
+	}
+	best, value := EmptySet(gs.GetType()), f.Value(EmptySet(gs.GetType()))
+	for mask := uint64(1); mask < 1<<uint(len(elms)); mask++ {
+		s := subsetOf(gs.GetType(), elms, mask)
+		if v := f.Value(s); v < value-submodularTolerance {
+			best, value = s, v
+		}
+	}
+	return best, value
+}
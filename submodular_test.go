@@ -0,0 +1,70 @@
+package matroid
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPolymatroid(t *testing.T) {
+	gs := newTestSet(type1, 5)
+	elms := sortedElements(gs)
+	p := PolymatroidOf(NewUniformMatroid(gs, 2))
+	vector := func(values ...float64) map[string]float64 {
+		x := make(map[string]float64)
+		for i, v := range values {
+			x[elms[i].Key()] = v
+		}
+		return x
+	}
+
+	x, value := p.Greedy(vector(3, -1, 5, 4, 0))
+	if value != 9 {
+		t.Errorf("greedy value mismatch. expected: %d, actual: %f", 9, value)
+	}
+	if expected := vector(0, 0, 1, 1, 0); !sameVector(x, expected) {
+		t.Errorf("greedy vertex mismatch. expected: %v, actual: %v", expected, x)
+	}
+
+	cases := []struct {
+		name     string
+		x        map[string]float64
+		contains bool
+		base     bool
+	}{
+		{"vertex", vector(1, 1, 0, 0, 0), true, true},
+		{"interior", vector(0.4, 0.4, 0.4, 0.4, 0.4), true, true},
+		{"below base", vector(0.5, 0.5, 0, 0, 0), true, false},
+		{"violated element", vector(1.5, 0, 0, 0, 0), false, false},
+		{"violated pair", vector(0.9, 0.9, 0.9, 0, 0), false, false},
+		{"negative", vector(-1, 1, 1, 1, 0), false, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual, err := p.Contains(c.x); err != nil || actual != c.contains {
+				t.Errorf("membership mismatch. expected: %t, actual: %t (%v)", c.contains, actual, err)
+			}
+			if actual, err := p.InBasePolyhedron(c.x); err != nil || actual != c.base {
+				t.Errorf("base membership mismatch. expected: %t, actual: %t (%v)", c.base, actual, err)
+			}
+		})
+	}
+
+	s, v, err := p.Separate(vector(0.9, 0.9, 0.9, 0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(v+0.7) > submodularTolerance || s.Cardinality() != 3 {
+		t.Errorf("separation mismatch. expected: %f on 3 elements, actual: %f on %d", -0.7, v, s.Cardinality())
+	}
+
+	// every greedy vertex of a matroid rank function is the incidence vector of a base
+	order := append([]Element(nil), elms...)
+	order[0], order[4] = order[4], order[0]
+	if ok, err := p.InBasePolyhedron(GreedyVertex(p.Function(), order)); err != nil || !ok {
+		t.Error("greedy vertex is not in the base polyhedron")
+	}
+
+	if _, err := NewPolymatroid(NewSubmodularFunction(gs, func(*Set) float64 { return 1 })); err == nil {
+		t.Error("expected an error for a function not vanishing on the empty set")
+	}
+}
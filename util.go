@@ -1,5 +1,7 @@
 package matroid
 
+import "sort"
+
 func max(a, b int) int {
 	if a < b {
 		return b
@@ -13,3 +15,24 @@ func min(a, b int) int {
 	}
 	return b
 }
+
+// sortedElements() returns the elements of s ordered by Key().
+// Algorithms enumerating subsets use this to fix an index for each element.
+func sortedElements(s *Set) []Element {
+	elms := s.ToSlice()
+	sort.Slice(elms, func(i, j int) bool {
+		return elms[i].Key() < elms[j].Key()
+	})
+	return elms
+}
+
+// subsetOf() returns the Set of elements of elms whose bits are set in mask.
+func subsetOf(t ElementType, elms []Element, mask uint64) *Set {
+	s := EmptySet(t)
+	for i, e := range elms {
+		if mask&(1<<uint(i)) != 0 {
+			s.Add(e)
+		}
+	}
+	return s
+}